@@ -0,0 +1,7 @@
+#################################
+# Data source: clouding_backup  #
+#################################
+
+data "clouding_backup" "example" {
+  id = "86EAL1xB769Z4q2w"
+}
@@ -0,0 +1,11 @@
+#################################
+# Data source: clouding_flavors #
+#################################
+
+data "clouding_flavors" "all" {}
+
+# Cheapest flavor with at least 2 virtual cores
+locals {
+  two_cores_flavors = [for flavor in data.clouding_flavors.all.flavors : flavor if flavor.vcores >= 2]
+  flavor_id         = [for flavor in local.two_cores_flavors : flavor.id if flavor.price_per_hour == min(local.two_cores_flavors[*].price_per_hour...)][0]
+}
@@ -1,4 +1,3 @@
-
 ###############################
 # Data source: clouding_image #
 ###############################
@@ -6,3 +5,14 @@
 data "clouding_image" "example" {
   id = "wLQbN5nvg829JaeZ"
 }
+
+# Lookup by name
+data "clouding_image" "debian" {
+  name = "Debian 11 (64 Bit)"
+}
+
+# Lookup the latest Ubuntu LTS
+data "clouding_image" "ubuntu" {
+  name_regex  = "^Ubuntu \\d+\\.04 "
+  most_recent = true
+}
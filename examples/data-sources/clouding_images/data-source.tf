@@ -0,0 +1,24 @@
+################################
+# Data source: clouding_images #
+################################
+
+# Latest Ubuntu LTS image that can be accessed with an SSH key
+data "clouding_images" "ubuntu" {
+  filter {
+    name   = "name_regex"
+    values = ["^Ubuntu \\d+\\.04 "]
+  }
+
+  filter {
+    name   = "access_methods.ssh_key"
+    values = ["optional", "required"]
+  }
+
+  max_price_per_hour = 0
+  sort_by            = "name"
+  sort_order         = "desc"
+}
+
+output "ubuntu_image_id" {
+  value = data.clouding_images.ubuntu.images[0].id
+}
@@ -0,0 +1,11 @@
+#################################
+# Data source: clouding_server  #
+#################################
+
+data "clouding_server" "example" {
+  id = "ke8vlrXPjxO1oq3m"
+}
+
+output "public_ip" {
+  value = data.clouding_server.example.public_ip
+}
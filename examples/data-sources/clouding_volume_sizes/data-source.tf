@@ -0,0 +1,14 @@
+######################################
+# Data source: clouding_volume_sizes #
+######################################
+
+data "clouding_volume_sizes" "all" {}
+
+data "clouding_image" "windows" {
+  name = "Windows Server 2022 (English 64Bit)"
+}
+
+# Smallest allowed volume size that fits the image
+locals {
+  ssd_gb = min([for size in data.clouding_volume_sizes.all.volume_sizes : size.size_gb if size.size_gb >= data.clouding_image.windows.minimum_size_gb]...)
+}
@@ -7,3 +7,25 @@ resource "clouding_firewall" "example" {
   name        = "example"
   description = "example"
 }
+
+##### The rules can be declared inline, the firewall then holds exactly these rules.
+
+resource "clouding_firewall" "web" {
+  name        = "web"
+  description = "Web servers"
+
+  rule = [
+    {
+      source_ip      = "0.0.0.0/0"
+      protocol       = "tcp"
+      description    = "Allow http connections"
+      port_range_min = 80
+      port_range_max = 80
+    },
+    {
+      source_ip   = "10.0.0.0/8"
+      protocol    = "icmp"
+      description = "Allow ping from the private network"
+    },
+  ]
+}
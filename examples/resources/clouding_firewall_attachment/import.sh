@@ -0,0 +1 @@
+terraform import clouding_firewall_attachment.example w1EqyGl4zXgm6kbj/Q7y1OZWlknXmk6l3
@@ -0,0 +1,13 @@
+##########################################
+# Resource: clouding_firewall_attachment #
+##########################################
+
+resource "clouding_firewall" "maintenance" {
+  name        = "maintenance"
+  description = "Temporary access for maintenance"
+}
+
+resource "clouding_firewall_attachment" "example" {
+  firewall_id = clouding_firewall.maintenance.id
+  server_id   = clouding_server.example.id
+}
@@ -15,4 +15,26 @@ resource "clouding_firewall_rule" "example_rule" {
   description    = "Allow http connections"
   port_range_min = 80
   port_range_max = 80
+
+  # Create the new rule first when firewall_id changes and the rule is replaced
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+##### A rule can be disabled without deleting it, e.g. to close SSH during an incident.
+
+variable "ssh_enabled" {
+  type    = bool
+  default = true
+}
+
+resource "clouding_firewall_rule" "ssh" {
+  firewall_id    = clouding_firewall.example.id
+  source_ip      = "0.0.0.0/0"
+  protocol       = "tcp"
+  description    = "Allow ssh connections"
+  port_range_min = 22
+  port_range_max = 22
+  enabled        = var.ssh_enabled
 }
@@ -0,0 +1,14 @@
+####################################
+# Resource: clouding_server_reboot #
+####################################
+
+##### Reboot the server every time the rendered configuration changes.
+
+resource "clouding_server_reboot" "example" {
+  server_id = clouding_server.example.id
+  type      = "soft"
+
+  triggers = {
+    config = sha256(templatefile("${path.module}/app.conf.tftpl", { port = 8080 }))
+  }
+}
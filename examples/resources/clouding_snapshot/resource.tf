@@ -0,0 +1,18 @@
+###############################
+# Resource: clouding_snapshot #
+###############################
+
+resource "clouding_snapshot" "example" {
+  server_id       = clouding_server.example.id
+  name            = "snapshot"
+  description     = "Snapshot example"
+  shutdown_server = true
+}
+
+##################################
+# Data source: clouding_snapshot #
+##################################
+
+data "clouding_snapshot" "example" {
+  id = clouding_snapshot.example.id
+}
@@ -3,6 +3,7 @@ package clouding
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -26,22 +27,17 @@ const (
 	ERROR       = "errored"
 )
 
-func (a *API) GetAction(id string) (Action, error) {
+func (a *API) GetAction(ctx context.Context, id string) (Action, error) {
 	var action Action
 
-	response, err := a.sendRequest(http.MethodGet, fmt.Sprintf("%s/%s", ACTION_PATH, id), nil)
+	response, err := a.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", ACTION_PATH, id), nil)
 	if err != nil {
 		return action, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return action, fmt.Errorf("error getting action: %s", err)
-		}
-		return action, fmt.Errorf("error getting action: %s", errorResponse.Detail)
+		return action, fmt.Errorf("error getting action: %w", newAPIError(response))
 	}
 
 	err = json.NewDecoder(response.Body).Decode(&action)
@@ -52,31 +48,83 @@ func (a *API) GetAction(id string) (Action, error) {
 	return action, nil
 }
 
-func (a *API) WaitForAction(ctx context.Context, action *Action, waitTime time.Duration) error {
-	actionResponse, err := a.GetAction(action.ID)
-	if err != nil {
-		return err
+// ActionTimeoutError is returned by WaitForAction when the context is done
+// before the action finishes. It keeps the last observed action.
+type ActionTimeoutError = TimeoutError[Action]
+
+type waitConfig struct {
+	delay           time.Duration
+	maxPollInterval time.Duration
+}
+
+type waitOption func(*waitConfig)
+
+// WithWaitDelay sets the time to wait before checking the action for the first time.
+func WithWaitDelay(delay time.Duration) waitOption {
+	return func(c *waitConfig) {
+		c.delay = delay
 	}
+}
 
-	for actionResponse.Status == PENDING || actionResponse.Status == PROCESSING {
-		actionResponse, err = a.GetAction(action.ID)
-		if err != nil {
-			return err
-		}
-		time.Sleep(waitTime)
+// WithMaxPollInterval lets the poll interval double after every check until it reaches maxPollInterval.
+func WithMaxPollInterval(maxPollInterval time.Duration) waitOption {
+	return func(c *waitConfig) {
+		c.maxPollInterval = maxPollInterval
+	}
+}
+
+// ActionStateChangeConf returns the configuration to wait for an action to complete.
+func (a *API) ActionStateChangeConf(id string, pollInterval time.Duration, options ...waitOption) *StateChangeConf[Action] {
+	config := waitConfig{
+		maxPollInterval: pollInterval,
+	}
+	for _, option := range options {
+		option(&config)
 	}
 
-	if actionResponse.Status == ERROR {
-		return fmt.Errorf("action id: %s failed", action.ID)
+	return &StateChangeConf[Action]{
+		Name:    fmt.Sprintf("action %s", id),
+		Pending: []string{PENDING, PROCESSING},
+		Target:  []string{COMPLETED},
+		Refresh: func(ctx context.Context) (Action, string, error) {
+			action, err := a.GetAction(ctx, id)
+			return action, action.Status, err
+		},
+		Delay:           config.delay,
+		PollInterval:    pollInterval,
+		MaxPollInterval: config.maxPollInterval,
 	}
+}
 
-	// Update the action with the latest status
-	action.Status = actionResponse.Status
-	action.CompletedAt = actionResponse.CompletedAt
+// WaitForAction polls the action every pollInterval until it is no longer pending or in progress.
+// It returns an *ActionTimeoutError when the context is done before the action finishes.
+func (a *API) WaitForAction(ctx context.Context, action *Action, pollInterval time.Duration, options ...waitOption) error {
+	conf := a.ActionStateChangeConf(action.ID, pollInterval, options...)
+	conf.Initial = *action
+	conf.InitialStatus = action.Status
+	actionResponse, err := conf.WaitForState(ctx)
+	if actionResponse.ID != "" {
+		// Update the action with the latest status
+		action.Status = actionResponse.Status
+		action.CompletedAt = actionResponse.CompletedAt
+	}
 
-	if ctx.Err() != nil {
-		return ctx.Err()
+	var statusError *UnexpectedStatusError[Action]
+	if errors.As(err, &statusError) {
+		if statusError.Status == ERROR {
+			return fmt.Errorf("action id: %s failed", action.ID)
+		}
+		return fmt.Errorf("action id: %s finished with unexpected status: %s", action.ID, statusError.Status)
 	}
+	return err
+}
+
+// IterActions returns an iterator over all the actions, fetching one page at a time.
+func (a *API) IterActions() *Iterator[Action] {
+	return newIterator[Action](a, ACTION_PATH, "actions")
+}
 
-	return nil
+// ListActions returns all the actions, following every page of the list.
+func (a *API) ListActions(ctx context.Context) ([]Action, error) {
+	return a.IterActions().All(ctx)
 }
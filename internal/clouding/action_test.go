@@ -1,9 +1,13 @@
 package clouding
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,7 +36,7 @@ func TestGetActionID(t *testing.T) {
 		t.Errorf("getting error calling NewAPI:%s", err)
 	}
 
-	action, err := client.GetAction("N3V2ryXQjWa6pvok")
+	action, err := client.GetAction(context.Background(), "N3V2ryXQjWa6pvok")
 	if err != nil {
 		t.Errorf("getting error calling GetAction: %s", err)
 	}
@@ -45,3 +49,107 @@ func TestGetActionID(t *testing.T) {
 	assert.Equal(t, "m1LrZ3W8exDzN60o", action.ResourceID)
 	assert.Equal(t, "server", action.ResourceType)
 }
+
+func TestWaitForAction(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := PROCESSING
+		if atomic.AddInt32(&requests, 1) >= 3 {
+			status = COMPLETED
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(fmt.Sprintf(`{"id": "N3V2ryXQjWa6pvok", "status": "%s", "completedAt": "2023-01-03T11:55:05.0000000Z"}`, status)))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error calling NewAPI:%s", err)
+	}
+
+	action := Action{ID: "N3V2ryXQjWa6pvok", Status: PENDING}
+	err = client.WaitForAction(context.Background(), &action, time.Millisecond, WithWaitDelay(time.Millisecond), WithMaxPollInterval(4*time.Millisecond))
+	if err != nil {
+		t.Errorf("getting error calling WaitForAction: %s", err)
+	}
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	assert.Equal(t, COMPLETED, action.Status)
+	assert.Equal(t, "2023-01-03T11:55:05.0000000Z", action.CompletedAt)
+}
+
+func TestWaitForActionErrored(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"id": "N3V2ryXQjWa6pvok", "status": "errored"}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error calling NewAPI:%s", err)
+	}
+
+	action := Action{ID: "N3V2ryXQjWa6pvok", Status: PENDING}
+	err = client.WaitForAction(context.Background(), &action, time.Millisecond)
+	assert.EqualError(t, err, "action id: N3V2ryXQjWa6pvok failed")
+	assert.Equal(t, ERROR, action.Status)
+}
+
+func TestWaitForActionTimeout(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"id": "N3V2ryXQjWa6pvok", "status": "pending", "type": "create"}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error calling NewAPI:%s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	action := Action{ID: "N3V2ryXQjWa6pvok"}
+	err = client.WaitForAction(ctx, &action, time.Millisecond, WithMaxPollInterval(10*time.Millisecond))
+
+	var timeoutError *ActionTimeoutError
+	assert.ErrorAs(t, err, &timeoutError)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, PENDING, timeoutError.LastResult.Status)
+	assert.Equal(t, "create", timeoutError.LastResult.Type)
+}
+
+func TestWaitForActionTimeoutBeforeRefresh(t *testing.T) {
+	t.Parallel()
+	client, err := NewAPI("token123", WithEndpoint("http://127.0.0.1:0"))
+	if err != nil {
+		t.Errorf("getting error calling NewAPI:%s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	action := Action{ID: "N3V2ryXQjWa6pvok", Status: PENDING, Type: "create", ResourceID: "Q7y1OZWlknXmk6l3"}
+	err = client.WaitForAction(ctx, &action, time.Millisecond, WithWaitDelay(time.Hour))
+
+	var timeoutError *ActionTimeoutError
+	assert.ErrorAs(t, err, &timeoutError)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, action, timeoutError.LastResult)
+	assert.Equal(t, PENDING, timeoutError.LastStatus)
+}
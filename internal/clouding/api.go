@@ -2,18 +2,26 @@ package clouding
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
 	ENDPOINT = "https://api.clouding.io"
 	VERSION  = "v1"
+	TIMEOUT  = 60 * time.Second
 )
 
 type API struct {
-	Endpoint string
-	Token    string
+	Endpoint   string
+	Token      string
+	HTTPClient *http.Client
+	retry      retryPolicy
+	limiter    *rateLimiter
 }
 
 type ErrorResponse struct {
@@ -32,6 +40,10 @@ func NewAPI(token string, options ...option) (*API, error) {
 	api := API{
 		Endpoint: ENDPOINT,
 		Token:    token,
+		HTTPClient: &http.Client{
+			Timeout: TIMEOUT,
+		},
+		retry: defaultRetryPolicy(),
 	}
 
 	for _, option := range options {
@@ -50,19 +62,74 @@ func WithEndpoint(endpoint string) option {
 	}
 }
 
-func (a *API) sendRequest(method string, path string, body []byte) (*http.Response, error) {
-	request, err := http.NewRequest(method, fmt.Sprintf("%s/%s/%s", a.Endpoint, VERSION, path), bytes.NewBuffer(body))
-	if err != nil {
-		return nil, err
+// WithHTTPClient sets the http client shared by every request of the API.
+func WithHTTPClient(client *http.Client) option {
+	return func(a *API) error {
+		if client == nil {
+			return fmt.Errorf("http client cannot be nil")
+		}
+		a.HTTPClient = client
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit for a single request made by the http client. The
+// client is copied, so a client shared with WithHTTPClient, e.g. http.DefaultClient,
+// keeps its own timeout.
+func WithTimeout(timeout time.Duration) option {
+	return func(a *API) error {
+		if timeout < 0 {
+			return fmt.Errorf("timeout cannot be negative: %s", timeout)
+		}
+		client := *a.HTTPClient
+		client.Timeout = timeout
+		a.HTTPClient = &client
+		return nil
 	}
-	// Set headers
-	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set("X-API-KEY", a.Token)
-
-	client := &http.Client{}
-	response, err := client.Do(request)
-	if err != nil {
-		return nil, err
+}
+
+// sendRequest sends a request to the Clouding API, retrying it according to the
+// retry policy when the API is rate limiting or temporarily unavailable. Every
+// attempt waits for the rate limiter when it is configured.
+func (a *API) sendRequest(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
+	url := fmt.Sprintf("%s/%s/%s", a.Endpoint, VERSION, path)
+
+	for attempt := 0; ; attempt++ {
+		if a.limiter != nil {
+			if err := a.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(body))
+		if err != nil {
+			return nil, err
+		}
+		// Set headers
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-API-KEY", a.Token)
+
+		response, err := a.HTTPClient.Do(request)
+		if ctx.Err() != nil {
+			if response != nil {
+				drainBody(response)
+			}
+			return nil, ctx.Err()
+		}
+		if attempt >= a.retry.max || !shouldRetry(method, response, err) {
+			return response, err
+		}
+
+		wait := a.retry.backoff(attempt, response)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Retrying %s %s in %s, got error: %s", method, path, wait, err))
+		} else {
+			tflog.Warn(ctx, fmt.Sprintf("Retrying %s %s in %s, got status code: %d", method, path, wait, response.StatusCode))
+			drainBody(response)
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
 	}
-	return response, nil
 }
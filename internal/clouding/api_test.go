@@ -1,9 +1,11 @@
 package clouding
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -33,7 +35,7 @@ func TestSendRequest(t *testing.T) {
 			if err != nil {
 				t.Errorf("getting error creating NewAPI: %s", err)
 			}
-			response, err := client.sendRequest(http.MethodGet, "firewall", []byte{})
+			response, err := client.sendRequest(context.Background(), http.MethodGet, "firewall", []byte{})
 			if err != nil {
 				t.Errorf("getting error sending request to %s: %s", server.URL, err)
 			}
@@ -41,3 +43,43 @@ func TestSendRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestSendRequestContextCanceled(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.sendRequest(ctx, http.MethodGet, "firewall", nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	t.Parallel()
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+
+	client, err := NewAPI("token123", WithHTTPClient(httpClient), WithTimeout(10*time.Second))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+	assert.Equal(t, 10*time.Second, client.HTTPClient.Timeout)
+	// The shared client keeps its own timeout
+	assert.Equal(t, 5*time.Second, httpClient.Timeout)
+
+	client, err = NewAPI("token123", WithHTTPClient(httpClient))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+	assert.Same(t, httpClient, client.HTTPClient)
+
+	_, err = NewAPI("token123", WithHTTPClient(nil))
+	assert.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package clouding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -15,25 +16,21 @@ type Backup struct {
 	CreatedAt    string `json:"createdAt"`
 	ServerID     string `json:"serverId"`
 	ServerName   string `json:"serverName"`
-	VolumeSizeGb int    `json:"volumeSizeGb"`
+	VolumeSizeGb int64  `json:"volumeSizeGb"`
 	Image        Image  `json:"image"`
 	Status       string `json:"status"`
 }
 
-func (a *API) GetBackupID(id string) (Backup, error) {
+func (a *API) GetBackupID(ctx context.Context, id string) (Backup, error) {
 	var backup Backup
 
-	response, err := a.sendRequest(http.MethodGet, fmt.Sprintf("%s/%s", BACKUP_PATH, id), nil)
+	response, err := a.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", BACKUP_PATH, id), nil)
 	if err != nil {
-		return backup, fmt.Errorf("getting error from sendRequest: %s", err)
+		return backup, fmt.Errorf("getting error from sendRequest: %w", err)
 	}
+	defer response.Body.Close()
 	if response.StatusCode != http.StatusOK {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return backup, fmt.Errorf("error decoding error response: %s", err)
-		}
-		return backup, fmt.Errorf("error getting backup, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
+		return backup, fmt.Errorf("error getting backup: %w", newAPIError(response))
 	}
 
 	err = json.NewDecoder(response.Body).Decode(&backup)
@@ -43,3 +40,13 @@ func (a *API) GetBackupID(id string) (Backup, error) {
 
 	return backup, nil
 }
+
+// IterBackups returns an iterator over all the backups, fetching one page at a time.
+func (a *API) IterBackups() *Iterator[Backup] {
+	return newIterator[Backup](a, BACKUP_PATH, "backups")
+}
+
+// ListBackups returns all the backups, following every page of the list.
+func (a *API) ListBackups(ctx context.Context) ([]Backup, error) {
+	return a.IterBackups().All(ctx)
+}
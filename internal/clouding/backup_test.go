@@ -1,6 +1,7 @@
 package clouding
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -21,6 +22,7 @@ func TestGetBackupID(t *testing.T) {
 		  "serverId": "mawqYZWOojWQyOV0",
 		  "serverName": "my-test-server",
 		  "volumeSizeGb": 25,
+		  "status": "Completed",
 		  "image": {
 		    "id": "lo1qJ9oZb1xGMEgD",
 		    "name": "CelestiaOS 2.04 (64 Bit)",
@@ -41,7 +43,7 @@ func TestGetBackupID(t *testing.T) {
 		t.Errorf("getting error creating NewAPI: %s", err)
 	}
 
-	backup, err := client.GetBackupID("86EAL1xB769Z4q2w")
+	backup, err := client.GetBackupID(context.Background(), "86EAL1xB769Z4q2w")
 	if err != nil {
 		t.Errorf("getting error calling GetBackupID: %s", err)
 	}
@@ -50,7 +52,8 @@ func TestGetBackupID(t *testing.T) {
 	assert.Equal(t, "2023-01-01T12:00:00.0000000Z", backup.CreatedAt)
 	assert.Equal(t, "mawqYZWOojWQyOV0", backup.ServerID)
 	assert.Equal(t, "my-test-server", backup.ServerName)
-	assert.Equal(t, 25, backup.VolumeSizeGb)
+	assert.Equal(t, int64(25), backup.VolumeSizeGb)
+	assert.Equal(t, "Completed", backup.Status)
 	assert.Equal(t, "lo1qJ9oZb1xGMEgD", backup.Image.ID)
 	assert.Equal(t, "CelestiaOS 2.04 (64 Bit)", backup.Image.Name)
 	assert.Equal(t, "optional", backup.Image.AccessMethods.SshKey)
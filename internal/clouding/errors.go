@@ -0,0 +1,100 @@
+package clouding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is returned when the Clouding API answers with an unexpected status
+// code. It keeps the whole problem details of the response.
+type APIError struct {
+	ErrorResponse
+	StatusCode int
+	Method     string
+	Path       string
+}
+
+// newAPIError builds an APIError from an unexpected response. The body is decoded
+// as problem details when possible, otherwise the HTTP status text is used as title.
+func newAPIError(response *http.Response) *APIError {
+	apiError := &APIError{
+		StatusCode: response.StatusCode,
+	}
+	if response.Request != nil {
+		apiError.Method = response.Request.Method
+		apiError.Path = response.Request.URL.Path
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil || json.Unmarshal(body, &apiError.ErrorResponse) != nil {
+		apiError.ErrorResponse = ErrorResponse{
+			Detail: strings.TrimSpace(string(body)),
+		}
+	}
+	if apiError.Title == "" {
+		apiError.Title = http.StatusText(response.StatusCode)
+	}
+
+	return apiError
+}
+
+func (e *APIError) Error() string {
+	var message strings.Builder
+	fmt.Fprintf(&message, "%s %s returned status code: %d, title: %s", e.Method, e.Path, e.StatusCode, e.Title)
+	if e.Detail != "" {
+		fmt.Fprintf(&message, ", detail: %s", e.Detail)
+	}
+	for _, fieldErrors := range e.Errors {
+		fields := make([]string, 0, len(fieldErrors))
+		for field := range fieldErrors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			fmt.Fprintf(&message, ", %s: %s", field, strings.Join(fieldErrors[field], " "))
+		}
+	}
+	if e.TraceID != "" {
+		fmt.Fprintf(&message, ", trace id: %s", e.TraceID)
+	}
+	return message.String()
+}
+
+// hasStatus reports whether err is an APIError with any of the given status codes.
+func hasStatus(err error, statusCodes ...int) bool {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+	for _, statusCode := range statusCodes {
+		if apiError.StatusCode == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err was caused by a resource that does not exist.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err was caused by a conflict with the current state of a resource.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited reports whether err was caused by the API rate limit.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsValidation reports whether err was caused by an invalid request.
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
@@ -0,0 +1,92 @@
+package clouding
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIErrorHelpers(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		desc          string
+		status        int
+		body          string
+		isNotFound    bool
+		isConflict    bool
+		isRateLimited bool
+		isValidation  bool
+		title         string
+	}{
+		{
+			desc:       "Not found with problem details",
+			status:     http.StatusNotFound,
+			body:       `{"title": "Not Found", "status": 404, "detail": "Server not found", "traceId": "abc"}`,
+			isNotFound: true,
+			title:      "Not Found",
+		},
+		{
+			desc:       "Conflict without body",
+			status:     http.StatusConflict,
+			isConflict: true,
+			title:      "Conflict",
+		},
+		{
+			desc:          "Rate limited with plain text body",
+			status:        http.StatusTooManyRequests,
+			body:          "slow down",
+			isRateLimited: true,
+			title:         "Too Many Requests",
+		},
+		{
+			desc:         "Unprocessable entity",
+			status:       http.StatusUnprocessableEntity,
+			body:         `{"title": "Invalid flavor", "status": 422}`,
+			isValidation: true,
+			title:        "Invalid flavor",
+		},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/problem+json")
+				w.WriteHeader(tC.status)
+				_, err := w.Write([]byte(tC.body))
+				if err != nil {
+					t.Errorf("error writing response: %s", err)
+				}
+			}))
+			defer server.Close()
+
+			client, err := NewAPI("token123", WithEndpoint(server.URL), WithRetry(0, time.Millisecond, time.Millisecond))
+			if err != nil {
+				t.Errorf("getting error creating NewAPI: %s", err)
+			}
+			_, err = client.GetImageID(context.Background(), "d3mKbx4zd3XEQaqP")
+
+			assert.Equal(t, tC.isNotFound, IsNotFound(err))
+			assert.Equal(t, tC.isConflict, IsConflict(err))
+			assert.Equal(t, tC.isRateLimited, IsRateLimited(err))
+			assert.Equal(t, tC.isValidation, IsValidation(err))
+
+			var apiError *APIError
+			assert.ErrorAs(t, err, &apiError)
+			assert.Equal(t, tC.status, apiError.StatusCode)
+			assert.Equal(t, http.MethodGet, apiError.Method)
+			assert.Equal(t, "/v1/images/d3mKbx4zd3XEQaqP", apiError.Path)
+			assert.Equal(t, tC.title, apiError.Title)
+		})
+	}
+}
+
+func TestAPIErrorHelpersWithOtherErrors(t *testing.T) {
+	t.Parallel()
+	assert.False(t, IsNotFound(nil))
+	assert.False(t, IsNotFound(context.Canceled))
+}
@@ -1,13 +1,17 @@
 package clouding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 const (
-	FIREWALL_PATH = "firewalls"
+	FIREWALL_PATH     = "firewalls"
+	FIREWALLS_PENDING = "pending"
+	FIREWALLS_APPLIED = "applied"
 )
 
 type Firewall struct {
@@ -40,23 +44,29 @@ type FirewallAttachment struct {
 	ServerName string `json:"serverName"`
 }
 
+// firewallRuleUpdate is the body of a firewall rule update, only the description of a
+// rule can be changed, the enabled state has its own endpoints.
+type firewallRuleUpdate struct {
+	Description string `json:"description"`
+}
+
+// firewallAttachment is the body of the attach and detach firewall requests.
+type firewallAttachment struct {
+	ServerID string `json:"serverId"`
+}
+
 // GetFirewallID returns the firewall ID.
-func (a *API) GetFirewallID(id string) (Firewall, error) {
+func (a *API) GetFirewallID(ctx context.Context, id string) (Firewall, error) {
 	var firewall Firewall
 
-	response, err := a.sendRequest(http.MethodGet, fmt.Sprintf("%s/%s", FIREWALL_PATH, id), nil)
+	response, err := a.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", FIREWALL_PATH, id), nil)
 	if err != nil {
 		return firewall, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return firewall, fmt.Errorf("error decoding error response: %s", err)
-		}
-		return firewall, fmt.Errorf("error getting firewall: %s", errorResponse.Title)
+		return firewall, fmt.Errorf("error getting firewall: %w", newAPIError(response))
 	}
 
 	err = json.NewDecoder(response.Body).Decode(&firewall)
@@ -67,26 +77,20 @@ func (a *API) GetFirewallID(id string) (Firewall, error) {
 	return firewall, nil
 }
 
-func (a *API) CreateFirewall(firewall *Firewall) error {
+func (a *API) CreateFirewall(ctx context.Context, firewall *Firewall) error {
 	firewallJSON, err := json.Marshal(firewall)
 	if err != nil {
 		return fmt.Errorf("error marshaling firewall: %s", err)
 	}
 
-	response, err := a.sendRequest(http.MethodPost, FIREWALL_PATH, firewallJSON)
+	response, err := a.sendRequest(ctx, http.MethodPost, FIREWALL_PATH, firewallJSON)
 	if err != nil {
-		return fmt.Errorf("getting error from sendRequest: %s", err)
+		return fmt.Errorf("getting error from sendRequest: %w", err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusCreated {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return fmt.Errorf("error decoding error response: %s", err)
-		}
-		return fmt.Errorf("error creating firewall, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
-
+		return fmt.Errorf("error creating firewall: %w", newAPIError(response))
 	}
 	err = json.NewDecoder(response.Body).Decode(&firewall)
 	if err != nil {
@@ -96,63 +100,48 @@ func (a *API) CreateFirewall(firewall *Firewall) error {
 	return nil
 }
 
-func (a *API) UpdateFirewall(id string, firewall Firewall) error {
+func (a *API) UpdateFirewall(ctx context.Context, id string, firewall Firewall) error {
 	firewallJSON, err := json.Marshal(firewall)
 	if err != nil {
 		return fmt.Errorf("error marshaling firewall: %s", err)
 	}
-	response, err := a.sendRequest(http.MethodPatch, fmt.Sprintf("%s/%s", FIREWALL_PATH, id), firewallJSON)
+	response, err := a.sendRequest(ctx, http.MethodPatch, fmt.Sprintf("%s/%s", FIREWALL_PATH, id), firewallJSON)
 	if err != nil {
-		return fmt.Errorf("getting error from sendRequest: %s", err)
+		return fmt.Errorf("getting error from sendRequest: %w", err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return fmt.Errorf("error decoding error response: %s", err)
-		}
-		return fmt.Errorf("error updating firewall, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
+		return fmt.Errorf("error updating firewall: %w", newAPIError(response))
 	}
 
 	return nil
 }
 
-func (a *API) DeleteFirewall(id string) error {
-	response, err := a.sendRequest(http.MethodDelete, fmt.Sprintf("%s/%s", FIREWALL_PATH, id), nil)
+func (a *API) DeleteFirewall(ctx context.Context, id string) error {
+	response, err := a.sendRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", FIREWALL_PATH, id), nil)
 	if err != nil {
-		return fmt.Errorf("getting error from sendRequest: %s", err)
+		return fmt.Errorf("getting error from sendRequest: %w", err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return fmt.Errorf("error decoding error response: %s", err)
-		}
-		return fmt.Errorf("error deleting firewall, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
+		return fmt.Errorf("error deleting firewall: %w", newAPIError(response))
 	}
 
 	return nil
 }
 
-func (a *API) GetFirewallRule(id string) (FirewallRuleID, error) {
+func (a *API) GetFirewallRule(ctx context.Context, id string) (FirewallRuleID, error) {
 	var firewallRuleID FirewallRuleID
-	response, err := a.sendRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s", FIREWALL_PATH, "rules", id), nil)
+	response, err := a.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s/%s", FIREWALL_PATH, "rules", id), nil)
 	if err != nil {
 		return firewallRuleID, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return firewallRuleID, fmt.Errorf("error decoding error response: %s", err)
-		}
-		return firewallRuleID, fmt.Errorf("error getting firewall rule, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
+		return firewallRuleID, fmt.Errorf("error getting firewall rule: %w", newAPIError(response))
 	}
 	err = json.NewDecoder(response.Body).Decode(&firewallRuleID)
 	if err != nil {
@@ -161,25 +150,20 @@ func (a *API) GetFirewallRule(id string) (FirewallRuleID, error) {
 	return firewallRuleID, nil
 }
 
-func (a *API) CreateFirewallRule(rule *FirewallRuleID) error {
+func (a *API) CreateFirewallRule(ctx context.Context, rule *FirewallRuleID) error {
 	ruleJSON, err := json.Marshal(rule.FirewallRule)
 	if err != nil {
 		return fmt.Errorf("error marshaling firewall rule: %s", err)
 	}
 
-	response, err := a.sendRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s", FIREWALL_PATH, rule.FirewallID, "rules"), ruleJSON)
+	response, err := a.sendRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s/%s", FIREWALL_PATH, rule.FirewallID, "rules"), ruleJSON)
 	if err != nil {
-		return fmt.Errorf("getting error from sendRequest: %s", err)
+		return fmt.Errorf("getting error from sendRequest: %w", err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusCreated {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return fmt.Errorf("error decoding error response: %s", err)
-		}
-		return fmt.Errorf("error creating firewall rule, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
+		return fmt.Errorf("error creating firewall rule: %w", newAPIError(response))
 	}
 	err = json.NewDecoder(response.Body).Decode(&rule.FirewallRule)
 	if err != nil {
@@ -189,19 +173,136 @@ func (a *API) CreateFirewallRule(rule *FirewallRuleID) error {
 	return nil
 }
 
-func (a *API) DeleteFirewallRule(id string) error {
-	response, err := a.sendRequest(http.MethodDelete, fmt.Sprintf("%s/%s/%s", FIREWALL_PATH, "rules", id), nil)
+// UpdateFirewallRule changes the description of a firewall rule. The enabled state is changed
+// with EnableFirewallRule and DisableFirewallRule, the other values of the rule cannot be changed.
+func (a *API) UpdateFirewallRule(ctx context.Context, id, description string) error {
+	ruleJSON, err := json.Marshal(firewallRuleUpdate{Description: description})
+	if err != nil {
+		return fmt.Errorf("error marshaling firewall rule: %s", err)
+	}
+
+	response, err := a.sendRequest(ctx, http.MethodPatch, fmt.Sprintf("%s/%s/%s", FIREWALL_PATH, "rules", id), ruleJSON)
+	if err != nil {
+		return fmt.Errorf("getting error from sendRequest: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("error updating firewall rule: %w", newAPIError(response))
+	}
+
+	return nil
+}
+
+func (a *API) DeleteFirewallRule(ctx context.Context, id string) error {
+	response, err := a.sendRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s/%s", FIREWALL_PATH, "rules", id), nil)
+	if err != nil {
+		return fmt.Errorf("getting error from sendRequest: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("error deleting firewall rule: %w", newAPIError(response))
+	}
+	return nil
+}
+
+// AttachFirewall attaches the firewall to the server. The firewall is pending on the
+// server until it is applied, see WaitForServerFirewalls.
+func (a *API) AttachFirewall(ctx context.Context, id, serverID string) error {
+	return a.firewallAttachment(ctx, id, serverID, "attach")
+}
+
+// DetachFirewall detaches the firewall from the server. The change is pending on the
+// server until it is applied, see WaitForServerFirewalls.
+func (a *API) DetachFirewall(ctx context.Context, id, serverID string) error {
+	return a.firewallAttachment(ctx, id, serverID, "detach")
+}
+
+func (a *API) firewallAttachment(ctx context.Context, id, serverID, operation string) error {
+	attachmentJSON, err := json.Marshal(firewallAttachment{ServerID: serverID})
+	if err != nil {
+		return fmt.Errorf("error marshaling firewall attachment: %s", err)
+	}
+
+	response, err := a.sendRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s/%s", FIREWALL_PATH, id, operation), attachmentJSON)
+	if err != nil {
+		return fmt.Errorf("getting error from sendRequest: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("error requesting firewall %s: %w", operation, newAPIError(response))
+	}
+
+	return nil
+}
+
+// ServerFirewallsStateChangeConf returns the configuration to wait for the pending firewalls of a server to be applied.
+func (a *API) ServerFirewallsStateChangeConf(serverID string, pollInterval time.Duration, options ...waitOption) *StateChangeConf[Server] {
+	config := waitConfig{
+		maxPollInterval: pollInterval,
+	}
+	for _, option := range options {
+		option(&config)
+	}
+
+	return &StateChangeConf[Server]{
+		Name:    fmt.Sprintf("firewalls of server %s", serverID),
+		Pending: []string{FIREWALLS_PENDING},
+		Target:  []string{FIREWALLS_APPLIED},
+		Refresh: func(ctx context.Context) (Server, string, error) {
+			server := Server{ID: serverID}
+			err := a.GetServerID(ctx, &server)
+			if err != nil {
+				return server, "", err
+			}
+			if len(server.PendingFirewalls) > 0 {
+				return server, FIREWALLS_PENDING, nil
+			}
+			return server, FIREWALLS_APPLIED, nil
+		},
+		Delay:           config.delay,
+		PollInterval:    pollInterval,
+		MaxPollInterval: config.maxPollInterval,
+	}
+}
+
+// WaitForServerFirewalls polls the server every pollInterval until it has no pending firewalls.
+func (a *API) WaitForServerFirewalls(ctx context.Context, serverID string, pollInterval time.Duration, options ...waitOption) error {
+	_, err := a.ServerFirewallsStateChangeConf(serverID, pollInterval, options...).WaitForState(ctx)
+	return err
+}
+
+// EnableFirewallRule enables a disabled firewall rule, so it applies again.
+func (a *API) EnableFirewallRule(ctx context.Context, id string) error {
+	return a.toggleFirewallRule(ctx, id, "enable")
+}
+
+// DisableFirewallRule disables a firewall rule without deleting it.
+func (a *API) DisableFirewallRule(ctx context.Context, id string) error {
+	return a.toggleFirewallRule(ctx, id, "disable")
+}
+
+func (a *API) toggleFirewallRule(ctx context.Context, id, operation string) error {
+	response, err := a.sendRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s/%s/%s", FIREWALL_PATH, "rules", id, operation), nil)
 	if err != nil {
-		return fmt.Errorf("getting error from sendRequest: %s", err)
+		return fmt.Errorf("getting error from sendRequest: %w", err)
 	}
 	defer response.Body.Close()
+
 	if response.StatusCode != http.StatusNoContent {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return fmt.Errorf("error decoding error response: %s", err)
-		}
-		return fmt.Errorf("error deleting firewall rule, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
+		return fmt.Errorf("error requesting firewall rule %s: %w", operation, newAPIError(response))
 	}
+
 	return nil
 }
+
+// IterFirewalls returns an iterator over all the firewalls, fetching one page at a time.
+func (a *API) IterFirewalls() *Iterator[Firewall] {
+	return newIterator[Firewall](a, FIREWALL_PATH, "firewalls")
+}
+
+// ListFirewalls returns all the firewalls, following every page of the list.
+func (a *API) ListFirewalls(ctx context.Context) ([]Firewall, error) {
+	return a.IterFirewalls().All(ctx)
+}
@@ -1,10 +1,14 @@
 package clouding
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -64,7 +68,7 @@ func TestGetFirewallID(t *testing.T) {
 	if err != nil {
 		t.Errorf("getting error calling NewAPI:%s", err)
 	}
-	firewall, err := client.GetFirewallID("LywOkvx5LWAp28NP")
+	firewall, err := client.GetFirewallID(context.Background(), "LywOkvx5LWAp28NP")
 	if err != nil {
 		t.Errorf("getting error calling GetFirewallID: %s", err)
 	}
@@ -102,7 +106,7 @@ func TestCreateFirewall(t *testing.T) {
 		Name:        "My firewall",
 		Description: "A firewall that restricts network accesses to my server",
 	}
-	err = client.CreateFirewall(&firewall)
+	err = client.CreateFirewall(context.Background(), &firewall)
 	if err != nil {
 		t.Errorf("getting error calling CreateFirewall: %s", err)
 	}
@@ -129,7 +133,7 @@ func TestUpdateFirewall(t *testing.T) {
 		t.Errorf("getting error calling NewAPI:%s", err)
 	}
 
-	err = client.UpdateFirewall("ZPlL0kxDYQ9Q3Yb5", Firewall{
+	err = client.UpdateFirewall(context.Background(), "ZPlL0kxDYQ9Q3Yb5", Firewall{
 		NewName:        "the-new-name",
 		NewDescription: "The new description of the firewall",
 	})
@@ -174,10 +178,15 @@ func TestUpdateFirewallWithError(t *testing.T) {
 		Name:        "My firewall",
 		Description: "A firewall that restricts network accesses to my server",
 	}
-	err = client.UpdateFirewall("ZPlL0kxDYQ9Q3Yb5", firewall)
-	if err != nil {
-		assert.Equal(t, "error updating firewall, status code: 400, title: One or more validation errors occurred.", err.Error())
-	}
+	err = client.UpdateFirewall(context.Background(), "ZPlL0kxDYQ9Q3Yb5", firewall)
+	assert.Equal(t, "error updating firewall: PATCH /v1/firewalls/ZPlL0kxDYQ9Q3Yb5 returned status code: 400, title: One or more validation errors occurred., detail: Please refer to the errors property for additional details., propertyName: Validation error1. Validation error2., trace id: 00000000-0000-0000-0000-000000000000", err.Error())
+	assert.True(t, IsValidation(err))
+
+	var apiError *APIError
+	assert.ErrorAs(t, err, &apiError)
+	assert.Equal(t, http.StatusBadRequest, apiError.StatusCode)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000000", apiError.TraceID)
+	assert.Equal(t, []string{"Validation error1.", "Validation error2."}, apiError.Errors[0]["propertyName"])
 }
 
 func TestDeleteFirewall(t *testing.T) {
@@ -190,7 +199,7 @@ func TestDeleteFirewall(t *testing.T) {
 	if err != nil {
 		t.Errorf("getting error calling NewAPI:%s", err)
 	}
-	err = client.DeleteFirewall("mYaRvlx1OmXApk6N")
+	err = client.DeleteFirewall(context.Background(), "mYaRvlx1OmXApk6N")
 	if err != nil {
 		t.Errorf("getting error calling DeleteFirewall: %s", err)
 	}
@@ -226,10 +235,9 @@ func TestDeleteFirewallWithError(t *testing.T) {
 	if err != nil {
 		t.Errorf("getting error calling NewAPI:%s", err)
 	}
-	err = client.DeleteFirewall("mYaRvlx1OmXApk6N")
-	if err != nil {
-		assert.Equal(t, "error deleting firewall, status code: 400, title: One or more validation errors occurred.", err.Error())
-	}
+	err = client.DeleteFirewall(context.Background(), "mYaRvlx1OmXApk6N")
+	assert.Equal(t, "error deleting firewall: DELETE /v1/firewalls/mYaRvlx1OmXApk6N returned status code: 400, title: One or more validation errors occurred., detail: Please refer to the errors property for additional details., propertyName: Validation error1. Validation error2., trace id: 00000000-0000-0000-0000-000000000000", err.Error())
+	assert.True(t, IsValidation(err))
 }
 
 func TestGetFirewallRule(t *testing.T) {
@@ -260,7 +268,7 @@ func TestGetFirewallRule(t *testing.T) {
 	if err != nil {
 		t.Errorf("getting error calling NewAPI:%s", err)
 	}
-	firewallRule, err := client.GetFirewallRule("2OM84qx6aWdz7JGr")
+	firewallRule, err := client.GetFirewallRule(context.Background(), "2OM84qx6aWdz7JGr")
 	if err != nil {
 		t.Errorf("getting error calling GetFirewallRule: %s", err)
 	}
@@ -307,9 +315,150 @@ func TestCreateFirewallRule(t *testing.T) {
 			PortRangeMax: 65535,
 		},
 	}
-	err = client.CreateFirewallRule(&firewallRuleID)
+	err = client.CreateFirewallRule(context.Background(), &firewallRuleID)
 	if err != nil {
 		t.Errorf("getting error calling CreateFirewallRule: %s", err)
 	}
 	assert.Equal(t, "eAMVoaXqP9BLJwR6", firewallRuleID.FirewallRule.ID)
 }
+
+func TestUpdateFirewallRule(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/v1/firewalls/rules/eAMVoaXqP9BLJwR6", r.URL.Path)
+		var body map[string]any
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			t.Errorf("error decoding request: %s", err)
+		}
+		assert.Equal(t, map[string]any{"description": "Allow ssh from the office"}, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error calling NewAPI:%s", err)
+	}
+
+	err = client.UpdateFirewallRule(context.Background(), "eAMVoaXqP9BLJwR6", "Allow ssh from the office")
+	if err != nil {
+		t.Errorf("getting error calling UpdateFirewallRule: %s", err)
+	}
+}
+
+func TestAttachDetachFirewall(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		call func(*API) error
+		path string
+	}{
+		"attach": {
+			call: func(a *API) error {
+				return a.AttachFirewall(context.Background(), "mYaRvlx1OmXApk6N", "7y1OZWl2ZE9mk6l3")
+			},
+			path: "/v1/firewalls/mYaRvlx1OmXApk6N/attach",
+		},
+		"detach": {
+			call: func(a *API) error {
+				return a.DetachFirewall(context.Background(), "mYaRvlx1OmXApk6N", "7y1OZWl2ZE9mk6l3")
+			},
+			path: "/v1/firewalls/mYaRvlx1OmXApk6N/detach",
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, test.path, r.URL.Path)
+				var body map[string]any
+				err := json.NewDecoder(r.Body).Decode(&body)
+				if err != nil {
+					t.Errorf("error decoding request: %s", err)
+				}
+				assert.Equal(t, map[string]any{"serverId": "7y1OZWl2ZE9mk6l3"}, body)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client, err := NewAPI("token123", WithEndpoint(server.URL))
+			if err != nil {
+				t.Errorf("getting error calling NewAPI:%s", err)
+			}
+			err = test.call(client)
+			if err != nil {
+				t.Errorf("getting error calling %s: %s", name, err)
+			}
+		})
+	}
+}
+
+func TestWaitForServerFirewalls(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pending := `["mYaRvlx1OmXApk6N"]`
+		if atomic.AddInt32(&requests, 1) == 2 {
+			pending = `[]`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(fmt.Sprintf(`{"id": "7y1OZWl2ZE9mk6l3", "pendingFirewalls": %s, "firewalls": [{"id": "mYaRvlx1OmXApk6N"}]}`, pending)))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error calling NewAPI:%s", err)
+	}
+
+	err = client.WaitForServerFirewalls(context.Background(), "7y1OZWl2ZE9mk6l3", time.Millisecond)
+	if err != nil {
+		t.Errorf("getting error calling WaitForServerFirewalls: %s", err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestEnableDisableFirewallRule(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		call func(*API) error
+		path string
+	}{
+		"enable": {
+			call: func(a *API) error { return a.EnableFirewallRule(context.Background(), "eAMVoaXqP9BLJwR6") },
+			path: "/v1/firewalls/rules/eAMVoaXqP9BLJwR6/enable",
+		},
+		"disable": {
+			call: func(a *API) error { return a.DisableFirewallRule(context.Background(), "eAMVoaXqP9BLJwR6") },
+			path: "/v1/firewalls/rules/eAMVoaXqP9BLJwR6/disable",
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, test.path, r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client, err := NewAPI("token123", WithEndpoint(server.URL))
+			if err != nil {
+				t.Errorf("getting error calling NewAPI:%s", err)
+			}
+			err = test.call(client)
+			if err != nil {
+				t.Errorf("getting error calling %s: %s", name, err)
+			}
+		})
+	}
+}
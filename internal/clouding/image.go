@@ -1,6 +1,7 @@
 package clouding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -25,22 +26,17 @@ type ImageAccessMethod struct {
 	Password string `json:"password"`
 }
 
-func (a *API) GetImageID(id string) (Image, error) {
+func (a *API) GetImageID(ctx context.Context, id string) (Image, error) {
 	var image Image
 
-	response, err := a.sendRequest(http.MethodGet, fmt.Sprintf("%s/%s", IMAGE_PATH, id), nil)
+	response, err := a.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", IMAGE_PATH, id), nil)
 	if err != nil {
 		return image, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return image, fmt.Errorf("error decoding error response: %s", err)
-		}
-		return image, fmt.Errorf("error getting image: %s", errorResponse.Detail)
+		return image, fmt.Errorf("error getting image: %w", newAPIError(response))
 	}
 
 	err = json.NewDecoder(response.Body).Decode(&image)
@@ -49,3 +45,13 @@ func (a *API) GetImageID(id string) (Image, error) {
 	}
 	return image, nil
 }
+
+// IterImages returns an iterator over all the images, fetching one page at a time.
+func (a *API) IterImages() *Iterator[Image] {
+	return newIterator[Image](a, IMAGE_PATH, "images")
+}
+
+// ListImages returns all the images, following every page of the list.
+func (a *API) ListImages(ctx context.Context) ([]Image, error) {
+	return a.IterImages().All(ctx)
+}
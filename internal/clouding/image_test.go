@@ -1,6 +1,7 @@
 package clouding
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -34,7 +35,7 @@ func TestGetImageID(t *testing.T) {
 	if err != nil {
 		t.Errorf("getting error creating NewAPI: %s", err)
 	}
-	image, err := client.GetImageID("d3mKbx4zd3XEQaqP")
+	image, err := client.GetImageID(context.Background(), "d3mKbx4zd3XEQaqP")
 	if err != nil {
 		t.Errorf("getting error calling GetImageID: %s", err)
 	}
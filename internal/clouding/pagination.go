@@ -0,0 +1,157 @@
+package clouding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	PAGE_SIZE = 50
+)
+
+// pageLinks are the navigation links returned with every page of a list endpoint.
+type pageLinks struct {
+	First string `json:"first"`
+	Prev  string `json:"prev"`
+	Next  string `json:"next"`
+	Last  string `json:"last"`
+}
+
+// Iterator walks through all the objects of a list endpoint, requesting the
+// next page only when the current one has been consumed.
+//
+//	it := client.IterServers()
+//	for it.Next(ctx) {
+//		server := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	api  *API
+	key  string
+	next string
+
+	page  []T
+	index int
+	value T
+	err   error
+}
+
+// newIterator returns an iterator over the objects found under key in every page of path.
+func newIterator[T any](a *API, path, key string) *Iterator[T] {
+	return &Iterator[T]{
+		api:  a,
+		key:  key,
+		next: fmt.Sprintf("%s?page=1&pageSize=%d", path, PAGE_SIZE),
+	}
+}
+
+// Next advances the iterator to the next object. It returns false when there are
+// no more objects or an error happened, which is reported by Err.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for it.index >= len(it.page) {
+		if it.err != nil || it.next == "" {
+			return false
+		}
+		it.fetch(ctx)
+	}
+
+	it.value = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Value returns the current object.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All consumes the iterator and returns every remaining object.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	items := []T{}
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+func (it *Iterator[T]) fetch(ctx context.Context) {
+	current := it.next
+	it.page, it.index, it.next = nil, 0, ""
+
+	response, err := it.api.sendRequest(ctx, http.MethodGet, current, nil)
+	if err != nil {
+		it.err = fmt.Errorf("getting error from sendRequest: %w", err)
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		it.err = fmt.Errorf("error listing %s: %w", it.key, newAPIError(response))
+		return
+	}
+
+	var body map[string]json.RawMessage
+	err = json.NewDecoder(response.Body).Decode(&body)
+	if err != nil {
+		it.err = fmt.Errorf("error decoding %s: %s", it.key, err)
+		return
+	}
+
+	if items, ok := body[it.key]; ok {
+		err = json.Unmarshal(items, &it.page)
+		if err != nil {
+			it.err = fmt.Errorf("error decoding %s: %s", it.key, err)
+			return
+		}
+	}
+
+	var links pageLinks
+	if raw, ok := body["links"]; ok {
+		err = json.Unmarshal(raw, &links)
+		if err != nil {
+			it.err = fmt.Errorf("error decoding %s links: %s", it.key, err)
+			return
+		}
+	}
+
+	next, err := nextPagePath(links.Next)
+	if err != nil {
+		it.err = fmt.Errorf("error parsing %s next page: %s", it.key, err)
+		return
+	}
+	// Stop if the API keeps pointing to the same page, to avoid looping forever.
+	if next != current {
+		it.next = next
+	}
+}
+
+// nextPagePath turns the absolute next link of a page into a path relative to
+// the API version, so the request goes through the configured endpoint.
+func nextPagePath(link string) (string, error) {
+	if link == "" {
+		return "", nil
+	}
+
+	next, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+
+	path := strings.TrimPrefix(next.Path, "/")
+	path = strings.TrimPrefix(path, VERSION+"/")
+	if next.RawQuery != "" {
+		path += "?" + next.RawQuery
+	}
+	return path, nil
+}
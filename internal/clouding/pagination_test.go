@@ -0,0 +1,177 @@
+package clouding
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIteratorPages(t *testing.T) {
+	t.Parallel()
+	var pages []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.URL.Query().Get("page"))
+		assert.Equal(t, "/v1/firewalls", r.URL.Path)
+		assert.Equal(t, fmt.Sprint(PAGE_SIZE), r.URL.Query().Get("pageSize"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		var body string
+		switch r.URL.Query().Get("page") {
+		case "1":
+			body = fmt.Sprintf(`{
+			  "firewalls": [{"id": "LywOkvx5LWAp28NP", "name": "web"}, {"id": "JLB82xyP8aWOrqeN", "name": "db"}],
+			  "links": {"first": "%[1]s/v1/firewalls?page=1&pageSize=50", "prev": null, "next": "%[1]s/v1/firewalls?page=2&pageSize=50", "last": "%[1]s/v1/firewalls?page=2&pageSize=50"},
+			  "meta": {"total": 3}
+			}`, server.URL)
+		default:
+			body = fmt.Sprintf(`{
+			  "firewalls": [{"id": "wa7BmZXbRoXe2Mjn", "name": "mail"}],
+			  "links": {"first": "%[1]s/v1/firewalls?page=1&pageSize=50", "prev": "%[1]s/v1/firewalls?page=1&pageSize=50", "next": null, "last": "%[1]s/v1/firewalls?page=2&pageSize=50"},
+			  "meta": {"total": 3}
+			}`, server.URL)
+		}
+		_, err := w.Write([]byte(body))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	firewalls, err := client.ListFirewalls(context.Background())
+	if err != nil {
+		t.Errorf("getting error calling ListFirewalls: %s", err)
+	}
+
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Len(t, firewalls, 3)
+	assert.Equal(t, "LywOkvx5LWAp28NP", firewalls[0].ID)
+	assert.Equal(t, "wa7BmZXbRoXe2Mjn", firewalls[2].ID)
+}
+
+func TestIteratorLazy(t *testing.T) {
+	t.Parallel()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{
+		  "actions": [{"id": "N3V2ryXQjWa6pvok", "status": "completed"}],
+		  "links": {"next": "https://api.clouding.io/v1/actions?page=2&pageSize=50"}
+		}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	it := client.IterActions()
+	assert.True(t, it.Next(context.Background()))
+	assert.Equal(t, "N3V2ryXQjWa6pvok", it.Value().ID)
+	assert.Equal(t, 1, requests)
+	assert.NoError(t, it.Err())
+}
+
+func TestIteratorEmpty(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"backups": [], "links": {"next": null}, "meta": {"total": 0}}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	backups, err := client.ListBackups(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, backups)
+}
+
+func TestIteratorSamePage(t *testing.T) {
+	t.Parallel()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{
+		  "snapshots": [{"id": "xQ3jbNeJ2lDPqVWg"}],
+		  "links": {"next": "https://api.clouding.io/v1/snapshots?page=1&pageSize=50"}
+		}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	snapshots, err := client.ListSnapshots(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, 1, requests)
+}
+
+func TestIteratorError(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, err := w.Write([]byte(`{"title": "Unauthorized", "status": 401}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	it := client.IterImages()
+	assert.False(t, it.Next(context.Background()))
+	assert.False(t, it.Next(context.Background()))
+	assert.EqualError(t, it.Err(), "error listing images: GET /v1/images returned status code: 401, title: Unauthorized")
+	assert.True(t, hasStatus(it.Err(), http.StatusUnauthorized))
+}
+
+func TestNextPagePath(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"": "",
+		"https://api.clouding.io/v1/servers?page=2&pageSize=50": "servers?page=2&pageSize=50",
+		"/v1/keypairs?page=3&pageSize=20":                       "keypairs?page=3&pageSize=20",
+	}
+	for link, want := range tests {
+		path, err := nextPagePath(link)
+		assert.NoError(t, err)
+		assert.Equal(t, want, path)
+	}
+}
@@ -0,0 +1,88 @@
+package clouding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// rateLimiter is a token bucket shared by every request of the API. It is safe
+// for concurrent use.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a token bucket that refills requestsPerSecond tokens
+// every second and holds up to burst tokens.
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// WithRateLimit limits the number of requests sent to the Clouding API per second.
+// A value of zero disables the rate limiter.
+func WithRateLimit(requestsPerSecond float64) option {
+	return func(a *API) error {
+		if requestsPerSecond < 0 {
+			return fmt.Errorf("requests per second cannot be negative: %f", requestsPerSecond)
+		}
+		if requestsPerSecond == 0 {
+			a.limiter = nil
+			return nil
+		}
+		a.limiter = newRateLimiter(requestsPerSecond, int(math.Max(1, math.Ceil(requestsPerSecond))))
+		return nil
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller must
+// wait before using it.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel gives back a token taken by reserve that was not used.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
+
+// Wait blocks until a request is allowed to be sent or the context is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	wait := l.reserve()
+	if wait == 0 {
+		return nil
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Clouding API rate limit reached, throttling request for %s", wait))
+	if err := sleep(ctx, wait); err != nil {
+		l.cancel()
+		return err
+	}
+	return nil
+}
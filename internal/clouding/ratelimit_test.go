@@ -0,0 +1,76 @@
+package clouding
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRequestRateLimit(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL), WithRateLimit(20))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	// The bucket starts with 20 tokens, the 10 extra requests need half a second.
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := client.sendRequest(context.Background(), http.MethodGet, "servers", nil)
+			if err != nil {
+				t.Errorf("getting error sending request to %s: %s", server.URL, err)
+				return
+			}
+			response.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(30), atomic.LoadInt32(&requests))
+	assert.GreaterOrEqual(t, time.Since(start), 450*time.Millisecond)
+}
+
+func TestRateLimiterWaitContextCanceled(t *testing.T) {
+	t.Parallel()
+	limiter := newRateLimiter(1, 1)
+	assert.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestWithRateLimit(t *testing.T) {
+	t.Parallel()
+	_, err := NewAPI("token123", WithRateLimit(-1))
+	assert.Error(t, err)
+
+	client, err := NewAPI("token123", WithRateLimit(0))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+	assert.Nil(t, client.limiter)
+
+	client, err = NewAPI("token123", WithRateLimit(0.5))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+	assert.Equal(t, float64(1), client.limiter.burst)
+}
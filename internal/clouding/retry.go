@@ -0,0 +1,142 @@
+package clouding
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	RETRY_MAX      = 3
+	RETRY_WAIT_MIN = 1 * time.Second
+	RETRY_WAIT_MAX = 30 * time.Second
+)
+
+// retryPolicy describes how failed requests are retried by sendRequest.
+type retryPolicy struct {
+	max     int
+	waitMin time.Duration
+	waitMax time.Duration
+}
+
+func defaultRetryPolicy() retryPolicy {
+	return retryPolicy{
+		max:     RETRY_MAX,
+		waitMin: RETRY_WAIT_MIN,
+		waitMax: RETRY_WAIT_MAX,
+	}
+}
+
+// WithRetry sets the maximum number of retries and the bounds of the exponential
+// backoff used between attempts. Setting maxRetries to zero disables retries.
+func WithRetry(maxRetries int, waitMin, waitMax time.Duration) option {
+	return func(a *API) error {
+		if maxRetries < 0 {
+			return fmt.Errorf("max retries cannot be negative: %d", maxRetries)
+		}
+		if waitMin <= 0 || waitMax < waitMin {
+			return fmt.Errorf("invalid retry wait bounds, min: %s, max: %s", waitMin, waitMax)
+		}
+		a.retry = retryPolicy{
+			max:     maxRetries,
+			waitMin: waitMin,
+			waitMax: waitMax,
+		}
+		return nil
+	}
+}
+
+// shouldRetry reports whether a request with the given method can be sent again
+// after getting the given response or transport error.
+//
+// Rate limited (429) and unavailable (503) responses are retried for every method
+// because the API rejects the request before processing it. Gateway errors and
+// transport errors are only retried for idempotent methods, as the request may
+// have reached the API.
+func shouldRetry(method string, response *http.Response, err error) bool {
+	if err != nil {
+		return isIdempotent(method)
+	}
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns the time to wait before the given retry attempt (starting at 0).
+// The Retry-After header of the response takes precedence, otherwise an
+// exponential backoff with full jitter is used. Both are bounded by the policy
+// maximum wait.
+func (p retryPolicy) backoff(attempt int, response *http.Response) time.Duration {
+	if response != nil {
+		if wait, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			if wait > p.waitMax {
+				wait = p.waitMax
+			}
+			return wait
+		}
+	}
+
+	wait := float64(p.waitMin) * math.Pow(2, float64(attempt))
+	if wait > float64(p.waitMax) {
+		wait = float64(p.waitMax)
+	}
+	// #nosec G404 -- jitter does not need a cryptographic source
+	return time.Duration(rand.Int63n(int64(wait)) + 1)
+}
+
+// parseRetryAfter parses a Retry-After header expressed either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// sleep waits for the given duration or until the context is done.
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// drainBody discards and closes the body so the connection can be reused.
+func drainBody(response *http.Response) {
+	_, _ = io.Copy(io.Discard, response.Body)
+	response.Body.Close()
+}
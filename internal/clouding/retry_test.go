@@ -0,0 +1,248 @@
+package clouding
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRequestRetry(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		desc             string
+		method           string
+		failures         int32
+		failureStatus    int
+		maxRetries       int
+		expectedStatus   int
+		expectedAttempts int32
+	}{
+		{
+			desc:             "GET is retried on bad gateway",
+			method:           http.MethodGet,
+			failures:         2,
+			failureStatus:    http.StatusBadGateway,
+			maxRetries:       3,
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 3,
+		},
+		{
+			desc:             "DELETE is retried on service unavailable",
+			method:           http.MethodDelete,
+			failures:         1,
+			failureStatus:    http.StatusServiceUnavailable,
+			maxRetries:       3,
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+		{
+			desc:             "POST is retried on too many requests",
+			method:           http.MethodPost,
+			failures:         2,
+			failureStatus:    http.StatusTooManyRequests,
+			maxRetries:       3,
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 3,
+		},
+		{
+			desc:             "POST is not retried on bad gateway",
+			method:           http.MethodPost,
+			failures:         1,
+			failureStatus:    http.StatusBadGateway,
+			maxRetries:       3,
+			expectedStatus:   http.StatusBadGateway,
+			expectedAttempts: 1,
+		},
+		{
+			desc:             "GET is not retried on bad request",
+			method:           http.MethodGet,
+			failures:         1,
+			failureStatus:    http.StatusBadRequest,
+			maxRetries:       3,
+			expectedStatus:   http.StatusBadRequest,
+			expectedAttempts: 1,
+		},
+		{
+			desc:             "GET gives up after max retries",
+			method:           http.MethodGet,
+			failures:         10,
+			failureStatus:    http.StatusServiceUnavailable,
+			maxRetries:       2,
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedAttempts: 3,
+		},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tC.method, r.Method)
+				if atomic.AddInt32(&attempts, 1) <= tC.failures {
+					w.WriteHeader(tC.failureStatus)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client, err := NewAPI("token123", WithEndpoint(server.URL), WithRetry(tC.maxRetries, time.Millisecond, 5*time.Millisecond))
+			if err != nil {
+				t.Errorf("getting error creating NewAPI: %s", err)
+			}
+			response, err := client.sendRequest(context.Background(), tC.method, "servers", []byte(`{"name":"test"}`))
+			if err != nil {
+				t.Errorf("getting error sending request to %s: %s", server.URL, err)
+			}
+			defer response.Body.Close()
+
+			assert.Equal(t, tC.expectedStatus, response.StatusCode)
+			assert.Equal(t, tC.expectedAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+func TestSendRequestRetryResendsBody(t *testing.T) {
+	t.Parallel()
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := make([]byte, 64)
+		n, _ := r.Body.Read(body)
+		assert.Equal(t, `{"name":"test"}`, string(body[:n]))
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL), WithRetry(1, time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+	response, err := client.sendRequest(context.Background(), http.MethodPost, "firewalls", []byte(`{"name":"test"}`))
+	if err != nil {
+		t.Errorf("getting error sending request to %s: %s", server.URL, err)
+	}
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+}
+
+func TestSendRequestRetryAfter(t *testing.T) {
+	t.Parallel()
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL), WithRetry(1, time.Millisecond, 2*time.Second))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	start := time.Now()
+	response, err := client.sendRequest(context.Background(), http.MethodGet, "servers", nil)
+	if err != nil {
+		t.Errorf("getting error sending request to %s: %s", server.URL, err)
+	}
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestSendRequestRetryContextCanceled(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.sendRequest(ctx, http.MethodGet, "servers", nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Parallel()
+	_, err := NewAPI("token123", WithRetry(-1, time.Second, time.Second))
+	assert.Error(t, err)
+
+	_, err = NewAPI("token123", WithRetry(3, 2*time.Second, time.Second))
+	assert.Error(t, err)
+
+	client, err := NewAPI("token123", WithRetry(5, time.Second, 10*time.Second))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+	assert.Equal(t, 5, client.retry.max)
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := client.retry.backoff(attempt, nil)
+		assert.Greater(t, wait, time.Duration(0))
+		assert.LessOrEqual(t, wait, 10*time.Second)
+	}
+}
+
+func TestBackoffRetryAfter(t *testing.T) {
+	t.Parallel()
+	policy := retryPolicy{max: 3, waitMin: time.Second, waitMax: 10 * time.Second}
+	tests := map[string]struct {
+		retryAfter string
+		expected   time.Duration
+	}{
+		"within the maximum": {
+			retryAfter: "3",
+			expected:   3 * time.Second,
+		},
+		"above the maximum": {
+			retryAfter: "3600",
+			expected:   10 * time.Second,
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			response := &http.Response{Header: http.Header{"Retry-After": []string{test.retryAfter}}}
+			assert.Equal(t, test.expected, policy.backoff(0, response))
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+	wait, ok := parseRetryAfter("3")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	wait, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
@@ -1,6 +1,7 @@
 package clouding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,6 +9,12 @@ import (
 
 const (
 	SERVER_PATH = "servers"
+
+	POWER_STATE_RUNNING   = "Running"
+	POWER_STATE_SHUTDOWN  = "Shutdown"
+	POWER_STATE_PAUSED    = "Paused"
+	POWER_STATE_SUSPENDED = "Suspended"
+	POWER_STATE_CRASHED   = "Crashed"
 )
 
 type Server struct {
@@ -71,21 +78,22 @@ type ServerCost struct {
 	PricePerMonthApprox float64 `json:"pricePerMonthApprox,omitempty"`
 }
 
-func (a *API) GetServerID(server *Server) error {
+// serverResize is the body of a server resize request.
+type serverResize struct {
+	FlavorID     string `json:"flavorId,omitempty"`
+	VolumeSizeGb int64  `json:"volumeSizeGb,omitempty"`
+}
+
+func (a *API) GetServerID(ctx context.Context, server *Server) error {
 
-	response, err := a.sendRequest(http.MethodGet, fmt.Sprintf("%s/%s", SERVER_PATH, server.ID), nil)
+	response, err := a.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", SERVER_PATH, server.ID), nil)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return fmt.Errorf("error decoding error response: %s", err)
-		}
-		return fmt.Errorf("error getting server: %s", errorResponse.Detail)
+		return fmt.Errorf("error getting server: %w", newAPIError(response))
 	}
 
 	err = json.NewDecoder(response.Body).Decode(&server)
@@ -93,7 +101,10 @@ func (a *API) GetServerID(server *Server) error {
 		return fmt.Errorf("error decoding server: %s", err)
 	}
 	server.FlavorID = server.Flavor
-	server.FirewallID = server.Firewalls[0].ID
+	// A server can have no firewall or several of them, FirewallID is the first one
+	if len(server.Firewalls) > 0 {
+		server.FirewallID = server.Firewalls[0].ID
+	}
 	if server.Volume != nil {
 		server.Volume.SsdGb = server.VolumeSizeGb
 		// FIXME: This is a workaround to avoid volume source value inconsistency"
@@ -103,26 +114,20 @@ func (a *API) GetServerID(server *Server) error {
 	return nil
 }
 
-func (a *API) CreateServer(server *Server) error {
+func (a *API) CreateServer(ctx context.Context, server *Server) error {
 	serverJSON, err := json.Marshal(server)
 	var serverResponse Server
 	if err != nil {
 		return fmt.Errorf("error marshaling server: %s", err)
 	}
 
-	response, err := a.sendRequest(http.MethodPost, SERVER_PATH, serverJSON)
+	response, err := a.sendRequest(ctx, http.MethodPost, SERVER_PATH, serverJSON)
 	if err != nil {
-		return fmt.Errorf("getting error from sendRequest: %s", err)
+		return fmt.Errorf("getting error from sendRequest: %w", err)
 	}
 	defer response.Body.Close()
 	if response.StatusCode != http.StatusAccepted {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return fmt.Errorf("error decoding error response: %s", err)
-		}
-		return fmt.Errorf("error creating server, status code: %d, title: %s, detail: %s", errorResponse.Status, errorResponse.Title, errorResponse.Detail)
-
+		return fmt.Errorf("error creating server: %w", newAPIError(response))
 	}
 
 	err = json.NewDecoder(response.Body).Decode(&serverResponse)
@@ -139,21 +144,16 @@ func (a *API) CreateServer(server *Server) error {
 	return nil
 }
 
-func (a *API) DeleteServer(id string) (Action, error) {
+func (a *API) DeleteServer(ctx context.Context, id string) (Action, error) {
 	var action Action
-	response, err := a.sendRequest(http.MethodDelete, fmt.Sprintf("%s/%s", SERVER_PATH, id), nil)
+	response, err := a.sendRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", SERVER_PATH, id), nil)
 	if err != nil {
-		return action, fmt.Errorf("getting error from sendRequest: %s", err)
+		return action, fmt.Errorf("getting error from sendRequest: %w", err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusAccepted {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return action, fmt.Errorf("error decoding error response: %s", err)
-		}
-		return action, fmt.Errorf("error deleting server, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
+		return action, fmt.Errorf("error deleting server: %w", newAPIError(response))
 	}
 
 	err = json.NewDecoder(response.Body).Decode(&action)
@@ -164,7 +164,7 @@ func (a *API) DeleteServer(id string) (Action, error) {
 	return action, nil
 }
 
-func (a *API) UpdateServerName(id, name string) error {
+func (a *API) UpdateServerName(ctx context.Context, id, name string) error {
 	server := Server{
 		NewServerName: name,
 	}
@@ -173,19 +173,103 @@ func (a *API) UpdateServerName(id, name string) error {
 		return fmt.Errorf("error marshaling server: %s", err)
 	}
 
-	response, err := a.sendRequest(http.MethodPatch, fmt.Sprintf("%s/%s/rename", SERVER_PATH, id), serverJSON)
+	response, err := a.sendRequest(ctx, http.MethodPatch, fmt.Sprintf("%s/%s/rename", SERVER_PATH, id), serverJSON)
 	if err != nil {
-		return fmt.Errorf("getting error from sendRequest: %s", err)
+		return fmt.Errorf("getting error from sendRequest: %w", err)
 	}
 	defer response.Body.Close()
 	if response.StatusCode != http.StatusNoContent {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return fmt.Errorf("error decoding error response: %s", err)
-		}
-		return fmt.Errorf("error updating server name, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
+		return fmt.Errorf("error updating server name: %w", newAPIError(response))
 	}
 
 	return nil
 }
+
+// ResizeServer changes the flavor of a server. The resize is done asynchronously,
+// the returned action can be waited to know when the server has the new flavor.
+func (a *API) ResizeServer(ctx context.Context, id, flavorID string) (Action, error) {
+	return a.resizeServer(ctx, id, serverResize{FlavorID: flavorID})
+}
+
+// ResizeServerVolume grows the volume of a server to volumeSizeGb gigabytes, volumes
+// cannot be shrunk. The returned action can be waited to know when the volume has the new size.
+func (a *API) ResizeServerVolume(ctx context.Context, id string, volumeSizeGb int64) (Action, error) {
+	return a.resizeServer(ctx, id, serverResize{VolumeSizeGb: volumeSizeGb})
+}
+
+func (a *API) resizeServer(ctx context.Context, id string, resize serverResize) (Action, error) {
+	var action Action
+	resizeJSON, err := json.Marshal(resize)
+	if err != nil {
+		return action, fmt.Errorf("error marshaling server resize: %s", err)
+	}
+
+	response, err := a.sendRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s/resize", SERVER_PATH, id), resizeJSON)
+	if err != nil {
+		return action, fmt.Errorf("getting error from sendRequest: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusAccepted {
+		return action, fmt.Errorf("error resizing server: %w", newAPIError(response))
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&action)
+	if err != nil {
+		return action, fmt.Errorf("error decoding action: %s", err)
+	}
+
+	return action, nil
+}
+
+// StartServer powers on a stopped server.
+func (a *API) StartServer(ctx context.Context, id string) (Action, error) {
+	return a.serverPowerAction(ctx, id, "start")
+}
+
+// StopServer powers off a running server.
+func (a *API) StopServer(ctx context.Context, id string) (Action, error) {
+	return a.serverPowerAction(ctx, id, "stop")
+}
+
+// RebootServer restarts a server gracefully.
+func (a *API) RebootServer(ctx context.Context, id string) (Action, error) {
+	return a.serverPowerAction(ctx, id, "reboot")
+}
+
+// HardRebootServer restarts a server by cutting its power, like pressing the reset button.
+func (a *API) HardRebootServer(ctx context.Context, id string) (Action, error) {
+	return a.serverPowerAction(ctx, id, "hard-reboot")
+}
+
+// serverPowerAction requests the power operation of a server, the returned action can be
+// waited to know when the operation is done.
+func (a *API) serverPowerAction(ctx context.Context, id, operation string) (Action, error) {
+	var action Action
+	response, err := a.sendRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s/%s", SERVER_PATH, id, operation), nil)
+	if err != nil {
+		return action, fmt.Errorf("getting error from sendRequest: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusAccepted {
+		return action, fmt.Errorf("error requesting server %s: %w", operation, newAPIError(response))
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&action)
+	if err != nil {
+		return action, fmt.Errorf("error decoding action: %s", err)
+	}
+
+	return action, nil
+}
+
+// IterServers returns an iterator over all the servers, fetching one page at a time.
+func (a *API) IterServers() *Iterator[Server] {
+	return newIterator[Server](a, SERVER_PATH, "servers")
+}
+
+// ListServers returns all the servers, following every page of the list.
+func (a *API) ListServers(ctx context.Context) ([]Server, error) {
+	return a.IterServers().All(ctx)
+}
@@ -1,6 +1,8 @@
 package clouding
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -104,7 +106,7 @@ func TestGetServerID(t *testing.T) {
 		},
 	}
 
-	err = client.GetServerID(&server)
+	err = client.GetServerID(context.Background(), &server)
 	if err != nil {
 		t.Errorf("getting error calling GetServerID: %s", err)
 	}
@@ -148,6 +150,31 @@ func TestGetServerID(t *testing.T) {
 	assert.Equal(t, 10.22292, server.Cost.PricePerMonthApprox)
 }
 
+func TestGetServerIDWithoutFirewalls(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"id": "ke8vlrXPjxO1oq3m", "flavor": "1x4", "firewalls": []}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+
+	client, err := NewAPI("token123", WithEndpoint(srv.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	server := Server{ID: "ke8vlrXPjxO1oq3m"}
+	err = client.GetServerID(context.Background(), &server)
+	if err != nil {
+		t.Errorf("getting error calling GetServerID: %s", err)
+	}
+	assert.Equal(t, "", server.FirewallID)
+	assert.Empty(t, server.Firewalls)
+}
+
 func TestCreateServer(t *testing.T) {
 	t.Parallel()
 	var server Server
@@ -222,7 +249,7 @@ func TestCreateServer(t *testing.T) {
 		BackupPreference:              &BackupPreference{},
 	}
 
-	err = client.CreateServer(&server)
+	err = client.CreateServer(context.Background(), &server)
 	if err != nil {
 		t.Errorf("getting error calling CreateServer: %s", err)
 	}
@@ -270,7 +297,7 @@ func TestDeleteServer(t *testing.T) {
 		t.Errorf("getting error creating NewAPI: %s", err)
 	}
 
-	action, err := client.DeleteServer("mR2Dn6xgLD9OMPyE")
+	action, err := client.DeleteServer(context.Background(), "mR2Dn6xgLD9OMPyE")
 	if err != nil {
 		t.Errorf("getting error calling DeleteServer: %s", err)
 	}
@@ -283,3 +310,200 @@ func TestDeleteServer(t *testing.T) {
 	assert.Equal(t, "7y1OZWl2ZE9mk6l3", action.ResourceID)
 	assert.Equal(t, "server", action.ResourceType)
 }
+
+func TestResizeServer(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/servers/7y1OZWl2ZE9mk6l3/resize", r.URL.Path)
+		var body map[string]any
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			t.Errorf("error decoding request: %s", err)
+		}
+		assert.Equal(t, map[string]any{"flavorId": "2x4"}, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, err = w.Write([]byte(`
+		{
+		  "id": "K9xnYDvBQ4mOlJRa",
+		  "status": "inProgress",
+		  "type": "resize",
+		  "startedAt": "2023-01-03T12:00:00.0000000Z",
+		  "completedAt": null,
+		  "resourceId": "7y1OZWl2ZE9mk6l3",
+		  "resourceType": "server"
+		}
+		`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+
+	client, err := NewAPI("token123", WithEndpoint(srv.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	action, err := client.ResizeServer(context.Background(), "7y1OZWl2ZE9mk6l3", "2x4")
+	if err != nil {
+		t.Errorf("getting error calling ResizeServer: %s", err)
+	}
+
+	assert.Equal(t, "K9xnYDvBQ4mOlJRa", action.ID)
+	assert.Equal(t, "inProgress", action.Status)
+	assert.Equal(t, "resize", action.Type)
+	assert.Equal(t, "7y1OZWl2ZE9mk6l3", action.ResourceID)
+}
+
+func TestResizeServerVolume(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/servers/7y1OZWl2ZE9mk6l3/resize", r.URL.Path)
+		var body map[string]any
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			t.Errorf("error decoding request: %s", err)
+		}
+		assert.Equal(t, map[string]any{"volumeSizeGb": float64(50)}, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, err = w.Write([]byte(`
+		{
+		  "id": "K9xnYDvBQ4mOlJRa",
+		  "status": "inProgress",
+		  "type": "resize",
+		  "startedAt": "2023-01-03T12:00:00.0000000Z",
+		  "completedAt": null,
+		  "resourceId": "7y1OZWl2ZE9mk6l3",
+		  "resourceType": "server"
+		}
+		`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+
+	client, err := NewAPI("token123", WithEndpoint(srv.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	action, err := client.ResizeServerVolume(context.Background(), "7y1OZWl2ZE9mk6l3", 50)
+	if err != nil {
+		t.Errorf("getting error calling ResizeServerVolume: %s", err)
+	}
+
+	assert.Equal(t, "K9xnYDvBQ4mOlJRa", action.ID)
+	assert.Equal(t, "inProgress", action.Status)
+	assert.Equal(t, "resize", action.Type)
+	assert.Equal(t, "7y1OZWl2ZE9mk6l3", action.ResourceID)
+}
+
+func TestServerPowerActions(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		call func(*API) (Action, error)
+		path string
+	}{
+		"start": {
+			call: func(a *API) (Action, error) { return a.StartServer(context.Background(), "7y1OZWl2ZE9mk6l3") },
+			path: "/v1/servers/7y1OZWl2ZE9mk6l3/start",
+		},
+		"stop": {
+			call: func(a *API) (Action, error) { return a.StopServer(context.Background(), "7y1OZWl2ZE9mk6l3") },
+			path: "/v1/servers/7y1OZWl2ZE9mk6l3/stop",
+		},
+		"reboot": {
+			call: func(a *API) (Action, error) { return a.RebootServer(context.Background(), "7y1OZWl2ZE9mk6l3") },
+			path: "/v1/servers/7y1OZWl2ZE9mk6l3/reboot",
+		},
+		"hard reboot": {
+			call: func(a *API) (Action, error) { return a.HardRebootServer(context.Background(), "7y1OZWl2ZE9mk6l3") },
+			path: "/v1/servers/7y1OZWl2ZE9mk6l3/hard-reboot",
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, test.path, r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusAccepted)
+				_, err := w.Write([]byte(`{"id": "K9xnYDvBQ4mOlJRa", "status": "inProgress", "resourceId": "7y1OZWl2ZE9mk6l3", "resourceType": "server"}`))
+				if err != nil {
+					t.Errorf("error writing response: %s", err)
+				}
+			}))
+			defer srv.Close()
+
+			client, err := NewAPI("token123", WithEndpoint(srv.URL))
+			if err != nil {
+				t.Errorf("getting error creating NewAPI: %s", err)
+			}
+
+			action, err := test.call(client)
+			if err != nil {
+				t.Errorf("getting error calling %s: %s", name, err)
+			}
+			assert.Equal(t, "K9xnYDvBQ4mOlJRa", action.ID)
+			assert.Equal(t, "7y1OZWl2ZE9mk6l3", action.ResourceID)
+		})
+	}
+}
+
+func TestListServers(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/servers", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`
+			{
+			  "servers": [
+			    {
+			      "id": "ke8vlrXPjxO1oq3m",
+			      "name": "database-server",
+			      "hostname": "db.example.com",
+			      "status": "Active",
+			      "powerState": "Running"
+			    }
+			  ],
+			  "links": {
+			    "first": "https://api.clouding.io/v1/servers?page=1&pageSize=50",
+			    "prev": null,
+			    "next": null,
+			    "last": "https://api.clouding.io/v1/servers?page=1&pageSize=50"
+			  },
+			  "meta": {
+			    "total": 1
+			  }
+			}
+		`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(srv.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	servers, err := client.ListServers(context.Background())
+	if err != nil {
+		t.Errorf("getting error calling ListServers: %s", err)
+	}
+
+	assert.Len(t, servers, 1)
+	assert.Equal(t, "ke8vlrXPjxO1oq3m", servers[0].ID)
+	assert.Equal(t, "database-server", servers[0].Name)
+	assert.Equal(t, "Running", servers[0].PowerState)
+}
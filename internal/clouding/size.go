@@ -0,0 +1,44 @@
+package clouding
+
+import (
+	"context"
+)
+
+const (
+	FLAVOR_PATH      = "sizes/flavors"
+	VOLUME_SIZE_PATH = "sizes/volumes"
+)
+
+type Flavor struct {
+	ID                  string  `json:"id"`
+	VCores              float64 `json:"vCores"`
+	RamGb               int64   `json:"ramGb"`
+	PricePerHour        float64 `json:"pricePerHour"`
+	PricePerMonthApprox float64 `json:"pricePerMonthApprox"`
+}
+
+type VolumeSize struct {
+	SizeGb              int64   `json:"sizeGb"`
+	PricePerHour        float64 `json:"pricePerHour"`
+	PricePerMonthApprox float64 `json:"pricePerMonthApprox"`
+}
+
+// IterFlavors returns an iterator over all the flavors, fetching one page at a time.
+func (a *API) IterFlavors() *Iterator[Flavor] {
+	return newIterator[Flavor](a, FLAVOR_PATH, "flavors")
+}
+
+// ListFlavors returns all the flavors, following every page of the list.
+func (a *API) ListFlavors(ctx context.Context) ([]Flavor, error) {
+	return a.IterFlavors().All(ctx)
+}
+
+// IterVolumeSizes returns an iterator over all the volume sizes, fetching one page at a time.
+func (a *API) IterVolumeSizes() *Iterator[VolumeSize] {
+	return newIterator[VolumeSize](a, VOLUME_SIZE_PATH, "volumeSizes")
+}
+
+// ListVolumeSizes returns all the volume sizes, following every page of the list.
+func (a *API) ListVolumeSizes(ctx context.Context) ([]VolumeSize, error) {
+	return a.IterVolumeSizes().All(ctx)
+}
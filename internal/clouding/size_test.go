@@ -0,0 +1,116 @@
+package clouding
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListFlavors(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/sizes/flavors", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`
+		{
+		  "flavors": [
+		    {
+		      "id": "0.5x1",
+		      "vCores": 0.5,
+		      "ramGb": 1,
+		      "pricePerHour": 0.00685,
+		      "pricePerMonthApprox": 5
+		    },
+		    {
+		      "id": "1x2",
+		      "vCores": 1,
+		      "ramGb": 2,
+		      "pricePerHour": 0.0137,
+		      "pricePerMonthApprox": 10
+		    }
+		  ],
+		  "links": {
+		    "next": null
+		  },
+		  "meta": {
+		    "total": 2
+		  }
+		}
+		`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	flavors, err := client.ListFlavors(context.Background())
+	if err != nil {
+		t.Errorf("getting error calling ListFlavors: %s", err)
+	}
+
+	assert.Len(t, flavors, 2)
+	assert.Equal(t, "0.5x1", flavors[0].ID)
+	assert.Equal(t, 0.5, flavors[0].VCores)
+	assert.Equal(t, int64(1), flavors[0].RamGb)
+	assert.Equal(t, 0.00685, flavors[0].PricePerHour)
+	assert.Equal(t, float64(10), flavors[1].PricePerMonthApprox)
+}
+
+func TestListVolumeSizes(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/sizes/volumes", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`
+		{
+		  "volumeSizes": [
+		    {
+		      "sizeGb": 5,
+		      "pricePerHour": 0.000685,
+		      "pricePerMonthApprox": 0.5
+		    },
+		    {
+		      "sizeGb": 10,
+		      "pricePerHour": 0.00137,
+		      "pricePerMonthApprox": 1
+		    }
+		  ],
+		  "links": {
+		    "next": null
+		  },
+		  "meta": {
+		    "total": 2
+		  }
+		}
+		`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	sizes, err := client.ListVolumeSizes(context.Background())
+	if err != nil {
+		t.Errorf("getting error calling ListVolumeSizes: %s", err)
+	}
+
+	assert.Len(t, sizes, 2)
+	assert.Equal(t, int64(5), sizes[0].SizeGb)
+	assert.Equal(t, 0.000685, sizes[0].PricePerHour)
+	assert.Equal(t, float64(1), sizes[1].PricePerMonthApprox)
+}
@@ -1,6 +1,7 @@
 package clouding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -22,25 +23,35 @@ type Snapshot struct {
 	Cost            SnapshotCost `json:"cost,omitempty"`
 }
 
+// snapshotCreate is the body of a snapshot creation request.
+type snapshotCreate struct {
+	Name           string `json:"name"`
+	Description    string `json:"description,omitempty"`
+	ShutDownServer bool   `json:"shutDownServer"`
+}
+
+// snapshotUpdate is the body of a snapshot update request, an empty description
+// clears the description of the snapshot.
+type snapshotUpdate struct {
+	NewName        string `json:"newName"`
+	NewDescription string `json:"newDescription"`
+}
+
 type SnapshotCost struct {
 	PricePerHour        float64 `json:"pricePerHour"`
 	PricePerMonthApprox float64 `json:"pricePerMonthApprox"`
 }
 
-func (a *API) GetSnapshotID(id string) (Snapshot, error) {
+func (a *API) GetSnapshotID(ctx context.Context, id string) (Snapshot, error) {
 	var snapshot Snapshot
 
-	response, err := a.sendRequest(http.MethodGet, fmt.Sprintf("%s/%s", SNAPSHOT_PATH, id), nil)
+	response, err := a.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", SNAPSHOT_PATH, id), nil)
 	if err != nil {
-		return snapshot, fmt.Errorf("getting error from sendRequest: %s", err)
+		return snapshot, fmt.Errorf("getting error from sendRequest: %w", err)
 	}
+	defer response.Body.Close()
 	if response.StatusCode != http.StatusOK {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return snapshot, fmt.Errorf("error decoding error response: %s", err)
-		}
-		return snapshot, fmt.Errorf("error getting snapshot, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
+		return snapshot, fmt.Errorf("error getting snapshot: %w", newAPIError(response))
 	}
 
 	err = json.NewDecoder(response.Body).Decode(&snapshot)
@@ -50,3 +61,87 @@ func (a *API) GetSnapshotID(id string) (Snapshot, error) {
 
 	return snapshot, nil
 }
+
+// CreateSnapshot creates a snapshot of the server volume. The resource id of the
+// returned action is the id of the new snapshot.
+func (a *API) CreateSnapshot(ctx context.Context, serverID, name, description string, shutdownServer bool) (Action, error) {
+	var action Action
+	snapshotJSON, err := json.Marshal(snapshotCreate{
+		Name:           name,
+		Description:    description,
+		ShutDownServer: shutdownServer,
+	})
+	if err != nil {
+		return action, fmt.Errorf("error marshaling snapshot: %s", err)
+	}
+
+	response, err := a.sendRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s/snapshot", SERVER_PATH, serverID), snapshotJSON)
+	if err != nil {
+		return action, fmt.Errorf("getting error from sendRequest: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusAccepted {
+		return action, fmt.Errorf("error creating snapshot: %w", newAPIError(response))
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&action)
+	if err != nil {
+		return action, fmt.Errorf("error decoding action: %s", err)
+	}
+
+	return action, nil
+}
+
+// UpdateSnapshot renames the snapshot and changes its description.
+func (a *API) UpdateSnapshot(ctx context.Context, id, name, description string) error {
+	snapshotJSON, err := json.Marshal(snapshotUpdate{
+		NewName:        name,
+		NewDescription: description,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshaling snapshot: %s", err)
+	}
+
+	response, err := a.sendRequest(ctx, http.MethodPatch, fmt.Sprintf("%s/%s", SNAPSHOT_PATH, id), snapshotJSON)
+	if err != nil {
+		return fmt.Errorf("getting error from sendRequest: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("error updating snapshot: %w", newAPIError(response))
+	}
+
+	return nil
+}
+
+func (a *API) DeleteSnapshot(ctx context.Context, id string) (Action, error) {
+	var action Action
+	response, err := a.sendRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", SNAPSHOT_PATH, id), nil)
+	if err != nil {
+		return action, fmt.Errorf("getting error from sendRequest: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusAccepted {
+		return action, fmt.Errorf("error deleting snapshot: %w", newAPIError(response))
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&action)
+	if err != nil {
+		return action, fmt.Errorf("error decoding action: %s", err)
+	}
+
+	return action, nil
+}
+
+// IterSnapshots returns an iterator over all the snapshots, fetching one page at a time.
+func (a *API) IterSnapshots() *Iterator[Snapshot] {
+	return newIterator[Snapshot](a, SNAPSHOT_PATH, "snapshots")
+}
+
+// ListSnapshots returns all the snapshots, following every page of the list.
+func (a *API) ListSnapshots(ctx context.Context) ([]Snapshot, error) {
+	return a.IterSnapshots().All(ctx)
+}
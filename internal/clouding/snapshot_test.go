@@ -1,6 +1,8 @@
 package clouding
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -46,7 +48,7 @@ func TestGetSnapShotID(t *testing.T) {
 		t.Errorf("getting error creating NewAPI: %s", err)
 	}
 
-	snapshot, err := client.GetSnapshotID("jDGPRJXLpGXeV5M1")
+	snapshot, err := client.GetSnapshotID(context.Background(), "jDGPRJXLpGXeV5M1")
 	if err != nil {
 		t.Errorf("getting error calling GetSnapshotID: %s", err)
 	}
@@ -64,3 +66,128 @@ func TestGetSnapShotID(t *testing.T) {
 	assert.Equal(t, 0.0021, snapshot.Cost.PricePerHour)
 	assert.Equal(t, 1.533, snapshot.Cost.PricePerMonthApprox)
 }
+
+func TestCreateSnapshot(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/servers/Q7y1OZWlknXmk6l3/snapshot", r.URL.Path)
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			t.Errorf("error decoding snapshot: %s", err)
+		}
+		assert.Equal(t, map[string]any{
+			"name":           "snapshot-with-mysql",
+			"description":    "A snapshot of the server after mysql installation",
+			"shutDownServer": true,
+		}, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, err = w.Write([]byte(`
+		{
+		  "id": "mR2Dn6xgLD9OMPyE",
+		  "status": "inProgress",
+		  "type": "snapshot",
+		  "startedAt": "2023-01-03T12:00:00.0000000Z",
+		  "completedAt": null,
+		  "resourceId": "jDGPRJXLpGXeV5M1",
+		  "resourceType": "snapshot"
+		}
+		`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	action, err := client.CreateSnapshot(context.Background(), "Q7y1OZWlknXmk6l3", "snapshot-with-mysql", "A snapshot of the server after mysql installation", true)
+	if err != nil {
+		t.Errorf("getting error calling CreateSnapshot: %s", err)
+	}
+
+	assert.Equal(t, "mR2Dn6xgLD9OMPyE", action.ID)
+	assert.Equal(t, "inProgress", action.Status)
+	assert.Equal(t, "jDGPRJXLpGXeV5M1", action.ResourceID)
+}
+
+func TestUpdateSnapshot(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		description string
+	}{
+		"new description": {
+			description: "The new description of the snapshot",
+		},
+		"clear description": {
+			description: "",
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				assert.Equal(t, http.MethodPatch, r.Method)
+				assert.Equal(t, "/v1/snapshots/jDGPRJXLpGXeV5M1", r.URL.Path)
+				err := json.NewDecoder(r.Body).Decode(&body)
+				if err != nil {
+					t.Errorf("error decoding snapshot: %s", err)
+				}
+				assert.Equal(t, map[string]any{"newName": "the-new-name", "newDescription": test.description}, body, name)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client, err := NewAPI("token123", WithEndpoint(server.URL))
+			if err != nil {
+				t.Errorf("getting error creating NewAPI: %s", err)
+			}
+
+			err = client.UpdateSnapshot(context.Background(), "jDGPRJXLpGXeV5M1", "the-new-name", test.description)
+			if err != nil {
+				t.Errorf("getting error calling UpdateSnapshot: %s", err)
+			}
+		})
+	}
+}
+
+func TestDeleteSnapshot(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, err := w.Write([]byte(`
+		{
+		  "id": "N3V2ryXQjWa6pvok",
+		  "status": "inProgress",
+		  "type": "delete",
+		  "resourceId": "jDGPRJXLpGXeV5M1",
+		  "resourceType": "snapshot"
+		}
+		`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	action, err := client.DeleteSnapshot(context.Background(), "jDGPRJXLpGXeV5M1")
+	if err != nil {
+		t.Errorf("getting error calling DeleteSnapshot: %s", err)
+	}
+
+	assert.Equal(t, "N3V2ryXQjWa6pvok", action.ID)
+	assert.Equal(t, "jDGPRJXLpGXeV5M1", action.ResourceID)
+}
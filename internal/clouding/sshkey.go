@@ -1,6 +1,7 @@
 package clouding
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -19,21 +20,17 @@ type SshKey struct {
 	HasPrivateKey bool   `json:"hasPrivateKey,omitempty"`
 }
 
-func (a *API) GetSshKeyID(id string) (SshKey, error) {
+func (a *API) GetSshKeyID(ctx context.Context, id string) (SshKey, error) {
 	var sshKey SshKey
 
-	response, err := a.sendRequest(http.MethodGet, fmt.Sprintf("%s/%s", SSHKEY_PATH, id), nil)
+	response, err := a.sendRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", SSHKEY_PATH, id), nil)
 	if err != nil {
-		return sshKey, fmt.Errorf("getting error from sendRequest: %s", err)
+		return sshKey, fmt.Errorf("getting error from sendRequest: %w", err)
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return sshKey, fmt.Errorf("error decoding error response: %s", err)
-		}
-		return sshKey, fmt.Errorf("error getting sshkey, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
+		return sshKey, fmt.Errorf("error getting sshkey: %w", newAPIError(response))
 	}
 
 	err = json.NewDecoder(response.Body).Decode(&sshKey)
@@ -44,25 +41,20 @@ func (a *API) GetSshKeyID(id string) (SshKey, error) {
 	return sshKey, nil
 }
 
-func (a *API) CreateSshKey(sshKey *SshKey) error {
+func (a *API) CreateSshKey(ctx context.Context, sshKey *SshKey) error {
 	sshKeyJSON, err := json.Marshal(sshKey)
 	if err != nil {
 		return fmt.Errorf("error marshaling sshkey: %s", err)
 	}
 
-	response, err := a.sendRequest(http.MethodPost, SSHKEY_PATH, sshKeyJSON)
+	response, err := a.sendRequest(ctx, http.MethodPost, SSHKEY_PATH, sshKeyJSON)
 	if err != nil {
-		return fmt.Errorf("getting error from sendRequest: %s", err)
+		return fmt.Errorf("getting error from sendRequest: %w", err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusCreated {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return fmt.Errorf("error decoding error response: %s", err)
-		}
-		return fmt.Errorf("error creating sshkey, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
+		return fmt.Errorf("error creating sshkey: %w", newAPIError(response))
 	}
 
 	err = json.NewDecoder(response.Body).Decode(sshKey)
@@ -72,21 +64,26 @@ func (a *API) CreateSshKey(sshKey *SshKey) error {
 	return nil
 }
 
-func (a *API) DeleteSshKey(id string) error {
-	response, err := a.sendRequest(http.MethodDelete, fmt.Sprintf("%s/%s", SSHKEY_PATH, id), nil)
+func (a *API) DeleteSshKey(ctx context.Context, id string) error {
+	response, err := a.sendRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", SSHKEY_PATH, id), nil)
 	if err != nil {
-		return fmt.Errorf("getting error from sendRequest: %s", err)
+		return fmt.Errorf("getting error from sendRequest: %w", err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		var errorResponse ErrorResponse
-		err = json.NewDecoder(response.Body).Decode(&errorResponse)
-		if err != nil {
-			return fmt.Errorf("error decoding error response: %s", err)
-		}
-		return fmt.Errorf("error deleting sshkey, status code: %d, title: %s", errorResponse.Status, errorResponse.Title)
+		return fmt.Errorf("error deleting sshkey: %w", newAPIError(response))
 	}
 
 	return nil
 }
+
+// IterSshKeys returns an iterator over all the SSH keys, fetching one page at a time.
+func (a *API) IterSshKeys() *Iterator[SshKey] {
+	return newIterator[SshKey](a, SSHKEY_PATH, "sshKeys")
+}
+
+// ListSshKeys returns all the SSH keys, following every page of the list.
+func (a *API) ListSshKeys(ctx context.Context) ([]SshKey, error) {
+	return a.IterSshKeys().All(ctx)
+}
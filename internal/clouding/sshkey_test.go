@@ -1,6 +1,7 @@
 package clouding
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -33,7 +34,7 @@ func TestGetSshKeyID(t *testing.T) {
 		t.Errorf("getting error creating NewAPI: %s", err)
 	}
 
-	sshkey, err := client.GetSshKeyID("Dd8v0nXJ1924rayY")
+	sshkey, err := client.GetSshKeyID(context.Background(), "Dd8v0nXJ1924rayY")
 	if err != nil {
 		t.Errorf("getting error calling GetSshKeyID: %s", err)
 	}
@@ -77,7 +78,7 @@ func TestCreateSshKey(t *testing.T) {
 		HasPrivateKey: false,
 	}
 
-	err = client.CreateSshKey(&sshkey)
+	err = client.CreateSshKey(context.Background(), &sshkey)
 	if err != nil {
 		t.Errorf("getting error calling CreateSshKey: %s", err)
 	}
@@ -100,8 +101,53 @@ func TestDeleteSshKey(t *testing.T) {
 		t.Errorf("getting error calling NewAPI:%s", err)
 	}
 
-	err = client.DeleteSshKey("jDGPRJXLpGXeV5M1")
+	err = client.DeleteSshKey(context.Background(), "jDGPRJXLpGXeV5M1")
 	if err != nil {
 		t.Errorf("getting error calling DeleteSshKey: %s", err)
 	}
 }
+
+func TestListSshKeys(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/keypairs", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`
+		{
+		  "sshKeys": [
+		    {
+		      "id": "Dd8v0nXJ1924rayY",
+		      "name": "my-key",
+		      "fingerprint": "SHA256:vHEbqsJB3n7D4TB6nEJe4ceJDtfXpMAv2RvoGdoH0hg",
+		      "hasPrivateKey": false
+		    }
+		  ],
+		  "links": {
+		    "next": null
+		  },
+		  "meta": {
+		    "total": 1
+		  }
+		}
+		`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPI("token123", WithEndpoint(server.URL))
+	if err != nil {
+		t.Errorf("getting error creating NewAPI: %s", err)
+	}
+
+	sshKeys, err := client.ListSshKeys(context.Background())
+	if err != nil {
+		t.Errorf("getting error calling ListSshKeys: %s", err)
+	}
+
+	assert.Len(t, sshKeys, 1)
+	assert.Equal(t, "Dd8v0nXJ1924rayY", sshKeys[0].ID)
+	assert.Equal(t, "my-key", sshKeys[0].Name)
+}
@@ -0,0 +1,144 @@
+package clouding
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RefreshFunc returns the latest version of the object being waited on and its status.
+type RefreshFunc[T any] func(ctx context.Context) (T, string, error)
+
+// StateChangeConf describes how to wait for an object to move from a set of pending
+// statuses to one of the target statuses.
+type StateChangeConf[T any] struct {
+	// Name identifies the object in logs and errors, e.g. "action N3V2ryXQjWa6pvok".
+	Name    string
+	Pending []string
+	Target  []string
+	Refresh RefreshFunc[T]
+
+	// Initial is the last known version of the object and InitialStatus its status. They
+	// are reported in the TimeoutError when the context is done before the first refresh.
+	Initial       T
+	InitialStatus string
+
+	// Delay is the time to wait before the first refresh.
+	Delay time.Duration
+	// PollInterval is the time to wait between refreshes. It doubles after every
+	// refresh until it reaches MaxPollInterval.
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+
+	// OnTransition is called every time the status of the object changes.
+	OnTransition func(ctx context.Context, from, to string, result T)
+}
+
+// TimeoutError is returned when the context is done before the object reaches a
+// target status. It keeps the last observed object.
+type TimeoutError[T any] struct {
+	Name       string
+	LastStatus string
+	LastResult T
+	Elapsed    time.Duration
+	Err        error
+}
+
+func (e *TimeoutError[T]) Error() string {
+	return fmt.Sprintf("timeout after %s waiting for %s, last status: %s: %s", e.Elapsed.Round(time.Second), e.Name, e.LastStatus, e.Err)
+}
+
+func (e *TimeoutError[T]) Unwrap() error {
+	return e.Err
+}
+
+// UnexpectedStatusError is returned when the object reaches a status that is
+// neither pending nor target.
+type UnexpectedStatusError[T any] struct {
+	Name       string
+	Status     string
+	Expected   []string
+	LastResult T
+}
+
+func (e *UnexpectedStatusError[T]) Error() string {
+	return fmt.Sprintf("unexpected status %q for %s, wanted one of: %s", e.Status, e.Name, strings.Join(e.Expected, ", "))
+}
+
+// WaitForState refreshes the object until it reaches a target status, an unexpected
+// status or the context is done. It returns the last refreshed object.
+func (c *StateChangeConf[T]) WaitForState(ctx context.Context) (T, error) {
+	lastResult := c.Initial
+	lastStatus := c.InitialStatus
+
+	maxPollInterval := c.MaxPollInterval
+	if maxPollInterval < c.PollInterval {
+		maxPollInterval = c.PollInterval
+	}
+
+	start := time.Now()
+	interval := c.PollInterval
+	timer := time.NewTimer(c.Delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return lastResult, c.timeoutError(ctx, lastStatus, lastResult, start)
+		case <-timer.C:
+		}
+
+		result, status, err := c.Refresh(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return lastResult, c.timeoutError(ctx, lastStatus, lastResult, start)
+			}
+			return lastResult, err
+		}
+
+		if status != lastStatus {
+			tflog.Debug(ctx, fmt.Sprintf("%s status changed from %q to %q", c.Name, lastStatus, status))
+			if c.OnTransition != nil {
+				c.OnTransition(ctx, lastStatus, status, result)
+			}
+		}
+		lastResult = result
+		lastStatus = status
+
+		switch {
+		case slices.Contains(c.Target, status):
+			return result, nil
+		case !slices.Contains(c.Pending, status):
+			return result, &UnexpectedStatusError[T]{
+				Name:       c.Name,
+				Status:     status,
+				Expected:   c.Target,
+				LastResult: result,
+			}
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("%s %s for %s", c.Name, status, time.Since(start).Round(time.Second)))
+
+		if interval < maxPollInterval {
+			interval *= 2
+			if interval > maxPollInterval {
+				interval = maxPollInterval
+			}
+		}
+		timer.Reset(interval)
+	}
+}
+
+func (c *StateChangeConf[T]) timeoutError(ctx context.Context, lastStatus string, lastResult T, start time.Time) error {
+	return &TimeoutError[T]{
+		Name:       c.Name,
+		LastStatus: lastStatus,
+		LastResult: lastResult,
+		Elapsed:    time.Since(start),
+		Err:        ctx.Err(),
+	}
+}
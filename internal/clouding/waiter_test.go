@@ -0,0 +1,152 @@
+package clouding
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRefreshFunc returns the given statuses in order, repeating the last one.
+func testRefreshFunc(statuses ...string) (RefreshFunc[int], *int) {
+	calls := 0
+	return func(ctx context.Context) (int, string, error) {
+		status := statuses[len(statuses)-1]
+		if calls < len(statuses) {
+			status = statuses[calls]
+		}
+		calls++
+		return calls, status, nil
+	}, &calls
+}
+
+func TestWaitForState(t *testing.T) {
+	t.Parallel()
+	refresh, calls := testRefreshFunc("pending", "pending", "inProgress", "completed")
+	var transitions []string
+	conf := StateChangeConf[int]{
+		Name:            "action N3V2ryXQjWa6pvok",
+		Pending:         []string{"pending", "inProgress"},
+		Target:          []string{"completed"},
+		Refresh:         refresh,
+		PollInterval:    time.Millisecond,
+		MaxPollInterval: 4 * time.Millisecond,
+		OnTransition: func(ctx context.Context, from, to string, result int) {
+			transitions = append(transitions, from+"->"+to)
+		},
+	}
+
+	result, err := conf.WaitForState(context.Background())
+	if err != nil {
+		t.Errorf("getting error calling WaitForState: %s", err)
+	}
+
+	assert.Equal(t, 4, result)
+	assert.Equal(t, 4, *calls)
+	assert.Equal(t, []string{"->pending", "pending->inProgress", "inProgress->completed"}, transitions)
+}
+
+func TestWaitForStateUnexpectedStatus(t *testing.T) {
+	t.Parallel()
+	refresh, _ := testRefreshFunc("pending", "errored")
+	conf := StateChangeConf[int]{
+		Name:         "action N3V2ryXQjWa6pvok",
+		Pending:      []string{"pending", "inProgress"},
+		Target:       []string{"completed"},
+		Refresh:      refresh,
+		PollInterval: time.Millisecond,
+	}
+
+	_, err := conf.WaitForState(context.Background())
+
+	var statusError *UnexpectedStatusError[int]
+	assert.ErrorAs(t, err, &statusError)
+	assert.Equal(t, "errored", statusError.Status)
+	assert.Equal(t, 2, statusError.LastResult)
+	assert.EqualError(t, err, `unexpected status "errored" for action N3V2ryXQjWa6pvok, wanted one of: completed`)
+}
+
+func TestWaitForStateRefreshError(t *testing.T) {
+	t.Parallel()
+	conf := StateChangeConf[int]{
+		Name:    "server Q7y1OZWlknXmk6l3",
+		Pending: []string{"pending"},
+		Target:  []string{"completed"},
+		Refresh: func(ctx context.Context) (int, string, error) {
+			return 0, "", errors.New("error getting server")
+		},
+		PollInterval: time.Millisecond,
+	}
+
+	_, err := conf.WaitForState(context.Background())
+	assert.EqualError(t, err, "error getting server")
+}
+
+func TestWaitForStateTimeout(t *testing.T) {
+	t.Parallel()
+	refresh, _ := testRefreshFunc("pending", "inProgress")
+	conf := StateChangeConf[int]{
+		Name:            "action N3V2ryXQjWa6pvok",
+		Pending:         []string{"pending", "inProgress"},
+		Target:          []string{"completed"},
+		Refresh:         refresh,
+		PollInterval:    time.Millisecond,
+		MaxPollInterval: 5 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	_, err := conf.WaitForState(ctx)
+
+	var timeoutError *TimeoutError[int]
+	assert.ErrorAs(t, err, &timeoutError)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "inProgress", timeoutError.LastStatus)
+	assert.Greater(t, timeoutError.LastResult, 1)
+}
+
+func TestWaitForStateDelay(t *testing.T) {
+	t.Parallel()
+	refresh, calls := testRefreshFunc("completed")
+	conf := StateChangeConf[int]{
+		Name:         "action N3V2ryXQjWa6pvok",
+		Target:       []string{"completed"},
+		Refresh:      refresh,
+		Delay:        time.Hour,
+		PollInterval: time.Millisecond,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := conf.WaitForState(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, *calls)
+}
+
+func TestWaitForStateTimeoutBeforeRefresh(t *testing.T) {
+	t.Parallel()
+	refresh, calls := testRefreshFunc("completed")
+	conf := StateChangeConf[int]{
+		Name:          "action N3V2ryXQjWa6pvok",
+		Target:        []string{"completed"},
+		Refresh:       refresh,
+		Initial:       42,
+		InitialStatus: "pending",
+		Delay:         time.Hour,
+		PollInterval:  time.Millisecond,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	result, err := conf.WaitForState(ctx)
+
+	var timeoutError *TimeoutError[int]
+	assert.ErrorAs(t, err, &timeoutError)
+	assert.Equal(t, 0, *calls)
+	assert.Equal(t, 42, result)
+	assert.Equal(t, 42, timeoutError.LastResult)
+	assert.Equal(t, "pending", timeoutError.LastStatus)
+}
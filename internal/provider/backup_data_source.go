@@ -3,12 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -20,17 +20,24 @@ func NewBackupDataSource() datasource.DataSource {
 
 // BackupDataSource defines the data source implementation.
 type BackupDataSource struct {
-	client *http.Client
+	client *clouding.API
 }
 
 // BackupDataSourceModel describes the data source data model.
 type BackupDataSourceModel struct {
-	Id           types.String         `tfsdk:"id"`
-	CreatedAt    types.String         `tfsdk:"created_at"`
-	ServerId     types.String         `tfsdk:"server_id"`
-	ServerName   types.String         `tfsdk:"server_name"`
-	VolumeSizeGb types.Number         `tfsdk:"volume_size_gb"`
-	Image        ImageDataSourceModel `tfsdk:"image"`
+	Id           types.String      `tfsdk:"id"`
+	CreatedAt    types.String      `tfsdk:"created_at"`
+	ServerId     types.String      `tfsdk:"server_id"`
+	ServerName   types.String      `tfsdk:"server_name"`
+	VolumeSizeGb types.Int64       `tfsdk:"volume_size_gb"`
+	Status       types.String      `tfsdk:"status"`
+	Image        *BackupImageModel `tfsdk:"image"`
+}
+
+type BackupImageModel struct {
+	Id            types.String             `tfsdk:"id"`
+	Name          types.String             `tfsdk:"name"`
+	AccessMethods *ImageAccessMethodsModel `tfsdk:"access_methods"`
 }
 
 func (d *BackupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -58,29 +65,47 @@ func (d *BackupDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				MarkdownDescription: "The name of the server that the backup was created from.",
 				Computed:            true,
 			},
-			"volume_size_gb": schema.NumberAttribute{
-				MarkdownDescription: "The size of the volume in gigabytes.",
+			"volume_size_gb": schema.Int64Attribute{
+				MarkdownDescription: "The size of the volume in gigabytes. It restricts the minimum volume size of a server created from the backup.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the backup.",
 				Computed:            true,
 			},
 			"image": schema.SingleNestedAttribute{
 				Computed:            true,
 				MarkdownDescription: "The image that the backup was created from.",
 				Attributes: map[string]schema.Attribute{
-					"ssh_key": schema.StringAttribute{
-						Computed: true,
-						MarkdownDescription: `Enum: ***not-supported*** ***optional*** ***required*** ***required-with-private-key***` +
-							`This is a secure way to access your server over the network. An SSH key pair consists of a public key and a private key. When the client attempts to connect to the server, the server checks if the public key matches the private key, and if so, grants access.` +
-							`- ***not-supported:*** SSH key is not supported for this image.` +
-							`- ***optional:*** Some images may support both SSH key authentication and password authentication. In this case, you can choose to use either method.` +
-							`- ***required:*** Some images may require SSH key authentication. This means you'll need to create an SSH key pair and provide its unique identifier when creating the server. You'll also need to have the private key stored on your client machine to access the virtual machine.` +
-							`- ***required-with-private-key:*** Some images may require that you use an SSH key with the private key stored in the Clouding servers. In this case, you'll need to either generate an SSH key or provide the private key when creating it.`,
+					"id": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "A unique string identifier used to reference an Image.",
+					},
+					"name": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The name of the Image.",
 					},
-					"password": schema.StringAttribute{
-						Computed: true,
-						MarkdownDescription: `Enum: ***not-supported*** ***optional*** ***required***` +
-							`- ***not-supported:*** Some images may not support password authentication, in which case you'll need to use an SSH key to access the machine.` +
-							`- ***optional:*** Some images may allow you to use either password authentication or SSH key authentication.` +
-							`- ***required:*** Some images may require a password for authentication. In this case, you'll need to provide a password when creating the server.`,
+					"access_methods": schema.SingleNestedAttribute{
+						Computed:            true,
+						MarkdownDescription: "The access methods of the Image.",
+						Attributes: map[string]schema.Attribute{
+							"ssh_key": schema.StringAttribute{
+								Computed: true,
+								MarkdownDescription: `Enum: ***not-supported*** ***optional*** ***required*** ***required-with-private-key***` +
+									`This is a secure way to access your server over the network. An SSH key pair consists of a public key and a private key. When the client attempts to connect to the server, the server checks if the public key matches the private key, and if so, grants access.` +
+									`- ***not-supported:*** SSH key is not supported for this image.` +
+									`- ***optional:*** Some images may support both SSH key authentication and password authentication. In this case, you can choose to use either method.` +
+									`- ***required:*** Some images may require SSH key authentication. This means you'll need to create an SSH key pair and provide its unique identifier when creating the server. You'll also need to have the private key stored on your client machine to access the virtual machine.` +
+									`- ***required-with-private-key:*** Some images may require that you use an SSH key with the private key stored in the Clouding servers. In this case, you'll need to either generate an SSH key or provide the private key when creating it.`,
+							},
+							"password": schema.StringAttribute{
+								Computed: true,
+								MarkdownDescription: `Enum: ***not-supported*** ***optional*** ***required***` +
+									`- ***not-supported:*** Some images may not support password authentication, in which case you'll need to use an SSH key to access the machine.` +
+									`- ***optional:*** Some images may allow you to use either password authentication or SSH key authentication.` +
+									`- ***required:*** Some images may require a password for authentication. In this case, you'll need to provide a password when creating the server.`,
+							},
+						},
 					},
 				},
 			},
@@ -94,12 +119,12 @@ func (d *BackupDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*clouding.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *clouding.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -118,16 +143,26 @@ func (d *BackupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := d.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
-
-	// For the purposes of this example code, hardcoding a response value to
+	backup, err := d.client.GetBackupID(ctx, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Backup",
+			err.Error(),
+		)
+		return
+	}
 	// save into the Terraform state.
+	state.Id = types.StringValue(backup.ID)
+	state.CreatedAt = types.StringValue(backup.CreatedAt)
+	state.ServerId = types.StringValue(backup.ServerID)
+	state.ServerName = types.StringValue(backup.ServerName)
+	state.VolumeSizeGb = types.Int64Value(backup.VolumeSizeGb)
+	state.Status = types.StringValue(backup.Status)
+	state.Image = &BackupImageModel{
+		Id:            types.StringValue(backup.Image.ID),
+		Name:          types.StringValue(backup.Image.Name),
+		AccessMethods: newImageAccessMethodsModel(backup.Image.AccessMethods),
+	}
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
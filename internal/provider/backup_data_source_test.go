@@ -1 +1,74 @@
-package provider
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupDataSource(t *testing.T) {
+	t.Parallel()
+	d := &provider.BackupDataSource{}
+	testConfigureDataSource(t, d, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/backups/86EAL1xB769Z4q2w", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`
+		{
+		  "id": "86EAL1xB769Z4q2w",
+		  "createdAt": "2023-01-01T12:00:00.0000000Z",
+		  "serverId": "mawqYZWOojWQyOV0",
+		  "serverName": "my-test-server",
+		  "volumeSizeGb": 25,
+		  "status": "Completed",
+		  "image": {
+		    "id": "lo1qJ9oZb1xGMEgD",
+		    "name": "CelestiaOS 2.04 (64 Bit)",
+		    "accessMethods": {
+		      "sshKey": "optional",
+		      "password": "required"
+		    }
+		  }
+		}
+		`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+
+	resp := testReadDataSource(t, d, &provider.BackupDataSourceModel{
+		Id: types.StringValue("86EAL1xB769Z4q2w"),
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state provider.BackupDataSourceModel
+	diags := resp.State.Get(context.Background(), &state)
+	assert.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, "86EAL1xB769Z4q2w", state.Id.ValueString())
+	assert.Equal(t, "2023-01-01T12:00:00.0000000Z", state.CreatedAt.ValueString())
+	assert.Equal(t, "mawqYZWOojWQyOV0", state.ServerId.ValueString())
+	assert.Equal(t, "my-test-server", state.ServerName.ValueString())
+	assert.Equal(t, int64(25), state.VolumeSizeGb.ValueInt64())
+	assert.Equal(t, "Completed", state.Status.ValueString())
+	assert.Equal(t, "lo1qJ9oZb1xGMEgD", state.Image.Id.ValueString())
+	assert.Equal(t, "CelestiaOS 2.04 (64 Bit)", state.Image.Name.ValueString())
+	assert.Equal(t, "optional", state.Image.AccessMethods.SshKey.ValueString())
+	assert.Equal(t, "required", state.Image.AccessMethods.Password.ValueString())
+}
+
+func TestBackupDataSourceNotFound(t *testing.T) {
+	t.Parallel()
+	d := &provider.BackupDataSource{}
+	testConfigureDataSource(t, d, testNotFoundHandler(t))
+
+	resp := testReadDataSource(t, d, &provider.BackupDataSourceModel{
+		Id: types.StringValue("86EAL1xB769Z4q2w"),
+	})
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "returned status code: 404")
+}
@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FirewallAttachmentResource{}
+var _ resource.ResourceWithImportState = &FirewallAttachmentResource{}
+
+func NewFirewallAttachmentResource() resource.Resource {
+	return &FirewallAttachmentResource{}
+}
+
+// FirewallAttachmentResource defines the resource implementation.
+type FirewallAttachmentResource struct {
+	client *clouding.API
+}
+
+// FirewallAttachmentResourceModel describes the resource data model.
+type FirewallAttachmentResourceModel struct {
+	Id         types.String   `tfsdk:"id"`
+	FirewallID types.String   `tfsdk:"firewall_id"`
+	ServerID   types.String   `tfsdk:"server_id"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *FirewallAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_attachment"
+}
+
+func (r *FirewallAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Attaches a firewall to a server, so firewalls can be rotated on running servers without replacing them. " +
+			"The attachment waits until the server has no pending firewalls. Do not manage the same firewall and server with the `firewall_id` of the server too.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the attachment, in the format `<firewall_id>/<server_id>`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"firewall_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The identifier of the firewall to attach.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"server_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The identifier of the server the firewall is attached to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *FirewallAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clouding.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clouding.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FirewallAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan FirewallAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.client.AttachFirewall(ctx, plan.FirewallID.ValueString(), plan.ServerID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to attach firewall, got error: %s", err))
+		return
+	}
+
+	// Wait until the server applies the firewall
+	err = r.client.WaitForServerFirewalls(ctx, plan.ServerID.ValueString(), 5*time.Second, clouding.WithMaxPollInterval(30*time.Second))
+	if err != nil {
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to wait for server firewalls, got error: %s", err))
+		return
+	}
+
+	plan.Id = types.StringValue(firewallAttachmentID(plan.FirewallID.ValueString(), plan.ServerID.ValueString()))
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("Firewall %s attached to server %s", plan.FirewallID.ValueString(), plan.ServerID.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FirewallAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state FirewallAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	firewall, err := r.client.GetFirewallID(ctx, state.FirewallID.ValueString())
+	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Firewall %s not found, removing the attachment from the state", state.FirewallID.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to read firewall, got error: %s", err))
+		return
+	}
+
+	attached := false
+	for _, attachment := range firewall.Attachments {
+		if attachment.ServerID == state.ServerID.ValueString() {
+			attached = true
+			break
+		}
+	}
+	if !attached {
+		tflog.Warn(ctx, fmt.Sprintf("Firewall %s is not attached to server %s, removing the attachment from the state", state.FirewallID.ValueString(), state.ServerID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Id = types.StringValue(firewallAttachmentID(state.FirewallID.ValueString(), state.ServerID.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only saves the timeouts, any other change replaces the attachment.
+func (r *FirewallAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan FirewallAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FirewallAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state FirewallAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// timeout
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DetachFirewall(ctx, state.FirewallID.ValueString(), state.ServerID.ValueString())
+	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Firewall %s or server %s already deleted", state.FirewallID.ValueString(), state.ServerID.ValueString()))
+			return
+		}
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to detach firewall, got error: %s", err))
+		return
+	}
+
+	// Wait until the server applies the detachment
+	err = r.client.WaitForServerFirewalls(ctx, state.ServerID.ValueString(), 5*time.Second, clouding.WithMaxPollInterval(30*time.Second))
+	if err != nil && !clouding.IsNotFound(err) {
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to wait for server firewalls, got error: %s", err))
+		return
+	}
+}
+
+// ImportState imports an attachment from an id in the format <firewall_id>/<server_id>.
+func (r *FirewallAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	firewallID, serverID, ok := strings.Cut(req.ID, "/")
+	if !ok || firewallID == "" || serverID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <firewall_id>/<server_id>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("firewall_id"), firewallID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), serverID)...)
+}
+
+func firewallAttachmentID(firewallID, serverID string) string {
+	return fmt.Sprintf("%s/%s", firewallID, serverID)
+}
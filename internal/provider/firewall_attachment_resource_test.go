@@ -0,0 +1,137 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+// testFirewallAttachmentHandler answers as the Clouding API with the firewall w1EqyGl4zXgm6kbj attached to the server Q7y1OZWlknXmk6l3.
+func testFirewallAttachmentHandler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/firewalls/w1EqyGl4zXgm6kbj/attach", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/v1/firewalls/w1EqyGl4zXgm6kbj", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{
+		  "id": "w1EqyGl4zXgm6kbj",
+		  "name": "web",
+		  "description": "web servers",
+		  "attachments": [{"serverId": "Q7y1OZWlknXmk6l3", "serverName": "testacc"}]
+		}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	})
+	mux.HandleFunc("/v1/servers/Q7y1OZWlknXmk6l3", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"id": "Q7y1OZWlknXmk6l3", "pendingFirewalls": [], "firewalls": [{"id": "w1EqyGl4zXgm6kbj"}]}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	})
+	mux.Handle("/", testNotFoundHandler(t))
+	return mux
+}
+
+func testFirewallAttachmentModel(firewallID, serverID string) *provider.FirewallAttachmentResourceModel {
+	return &provider.FirewallAttachmentResourceModel{
+		Id:         types.StringValue(firewallID + "/" + serverID),
+		FirewallID: types.StringValue(firewallID),
+		ServerID:   types.StringValue(serverID),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{"create": types.StringType, "delete": types.StringType}),
+		},
+	}
+}
+
+func TestFirewallAttachmentResourceCreate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := &provider.FirewallAttachmentResource{}
+	testConfigureResource(t, r, testFirewallAttachmentHandler(t))
+
+	model := testFirewallAttachmentModel("w1EqyGl4zXgm6kbj", "Q7y1OZWlknXmk6l3")
+	model.Id = types.StringUnknown()
+	plan := testResourcePlan(t, r, model)
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state provider.FirewallAttachmentResourceModel
+	resp.State.Get(ctx, &state)
+	assert.Equal(t, "w1EqyGl4zXgm6kbj/Q7y1OZWlknXmk6l3", state.Id.ValueString())
+}
+
+func TestFirewallAttachmentResourceRead(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		firewallID string
+		serverID   string
+		removed    bool
+	}{
+		"attached": {
+			firewallID: "w1EqyGl4zXgm6kbj",
+			serverID:   "Q7y1OZWlknXmk6l3",
+		},
+		"detached outside of terraform": {
+			firewallID: "w1EqyGl4zXgm6kbj",
+			serverID:   "mR2Dn6xgLD9OMPyE",
+			removed:    true,
+		},
+		"firewall not found": {
+			firewallID: "mYaRvlx1OmXApk6N",
+			serverID:   "Q7y1OZWlknXmk6l3",
+			removed:    true,
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := &provider.FirewallAttachmentResource{}
+			testConfigureResource(t, r, testFirewallAttachmentHandler(t))
+			state := testResourceState(t, r, testFirewallAttachmentModel(test.firewallID, test.serverID))
+
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, test.removed, resp.State.Raw.IsNull())
+		})
+	}
+}
+
+func TestFirewallAttachmentResourceImportState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := &provider.FirewallAttachmentResource{}
+	state := testResourceState(t, r, testFirewallAttachmentModel("", ""))
+	state.Raw = tftypes.NewValue(state.Raw.Type(), nil)
+
+	resp := fwresource.ImportStateResponse{State: state}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "w1EqyGl4zXgm6kbj/Q7y1OZWlknXmk6l3"}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var model provider.FirewallAttachmentResourceModel
+	resp.State.Get(ctx, &model)
+	assert.Equal(t, "w1EqyGl4zXgm6kbj", model.FirewallID.ValueString())
+	assert.Equal(t, "Q7y1OZWlknXmk6l3", model.ServerID.ValueString())
+
+	resp = fwresource.ImportStateResponse{State: state}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "w1EqyGl4zXgm6kbj"}, &resp)
+	assert.True(t, resp.Diagnostics.HasError())
+}
@@ -153,7 +153,7 @@ func (d *FirewallDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	response, err := d.client.GetFirewallID(state.Id.ValueString())
+	response, err := d.client.GetFirewallID(ctx, state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving Firewall",
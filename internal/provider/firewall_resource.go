@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
@@ -30,10 +33,49 @@ type FirewallResource struct {
 
 // FirewallResourceModel describes the resource data model.
 type FirewallResourceModel struct {
-	Id          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	Id          types.String              `tfsdk:"id"`
+	Name        types.String              `tfsdk:"name"`
+	Description types.String              `tfsdk:"description"`
+	Rules       []FirewallInlineRuleModel `tfsdk:"rule"`
+	LastUpdated types.String              `tfsdk:"last_updated"`
+}
+
+// FirewallInlineRuleModel describes a rule declared inline in a firewall.
+type FirewallInlineRuleModel struct {
+	SourceIP     types.String `tfsdk:"source_ip"`
+	Protocol     types.String `tfsdk:"protocol"`
+	Description  types.String `tfsdk:"description"`
+	PortRangeMin types.Int64  `tfsdk:"port_range_min"`
+	PortRangeMax types.Int64  `tfsdk:"port_range_max"`
+}
+
+// firewallRuleKey identifies a rule by its values, inline rules have no identifier.
+type firewallRuleKey struct {
+	SourceIP     string
+	Protocol     string
+	Description  string
+	PortRangeMin int64
+	PortRangeMax int64
+}
+
+func (m FirewallInlineRuleModel) key() firewallRuleKey {
+	return firewallRuleKey{
+		SourceIP:     m.SourceIP.ValueString(),
+		Protocol:     m.Protocol.ValueString(),
+		Description:  m.Description.ValueString(),
+		PortRangeMin: m.PortRangeMin.ValueInt64(),
+		PortRangeMax: m.PortRangeMax.ValueInt64(),
+	}
+}
+
+func firewallRuleKeyOf(rule clouding.FirewallRule) firewallRuleKey {
+	return firewallRuleKey{
+		SourceIP:     rule.SourceIP,
+		Protocol:     rule.Protocol,
+		Description:  rule.Description,
+		PortRangeMin: rule.PortRangeMin,
+		PortRangeMax: rule.PortRangeMax,
+	}
 }
 
 func (r *FirewallResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -43,7 +85,8 @@ func (r *FirewallResource) Metadata(ctx context.Context, req resource.MetadataRe
 func (r *FirewallResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language Firewall.
-		MarkdownDescription: "Create a Firewall with empty rules and attachments.",
+		MarkdownDescription: "Create a Firewall with empty rules and attachments. " +
+			"When `rule` is set the firewall holds exactly the declared rules, any other rule is deleted. In that case do not use `clouding_firewall_rule` resources for the same firewall.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -61,6 +104,38 @@ func (r *FirewallResource) Schema(ctx context.Context, req resource.SchemaReques
 				Required:            true,
 				MarkdownDescription: "The Firewall description. The description is displayed in the UI.",
 			},
+			"rule": schema.SetNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "The complete set of rules of the Firewall. Rules added or disabled outside of Terraform are reported as drift, on the next apply the added rules are deleted and the disabled ones are enabled again. If it is not set, the rules of the Firewall are not managed. An imported Firewall reads its rules into `rule`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_ip": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The IP or CIDR that the rule will be applied for.",
+						},
+						"protocol": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The protocol of the rule, e.g. tcp, udp or icmp.",
+						},
+						"description": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "A short description of the rule.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+								stringvalidator.LengthAtMost(512),
+							},
+						},
+						"port_range_min": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "The minimum port of the port range.",
+						},
+						"port_range_max": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "The maximum port of the port range.",
+						},
+					},
+				},
+			},
 			"last_updated": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The Firewall datetime update",
@@ -102,12 +177,30 @@ func (r *FirewallResource) Create(ctx context.Context, req resource.CreateReques
 		Name:        plan.Name.ValueString(),
 		Description: plan.Description.ValueString(),
 	}
-	err := r.client.CreateFirewall(&firewall)
+	err := r.client.CreateFirewall(ctx, &firewall)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create firewall resource, got error: %s", err))
 		return
 	}
 
+	if plan.Rules != nil {
+		r.syncRules(ctx, firewall.ID, plan.Rules, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			// Keep the firewall in the state with the rules it has, it exists even if some rules failed
+			plan.Id = types.StringValue(firewall.ID)
+			plan.Rules = []FirewallInlineRuleModel{}
+			plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+			created, err := r.client.GetFirewallID(ctx, firewall.ID)
+			if err != nil {
+				resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to read firewall rules, got error: %s", err))
+			} else {
+				plan.Rules = firewallInlineRules(created.Rules)
+			}
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			return
+		}
+	}
+
 	// save into the Terraform state.
 	plan.Id = types.StringValue(firewall.ID)
 	plan.Name = types.StringValue(firewall.Name)
@@ -132,13 +225,22 @@ func (r *FirewallResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	firewall, err := r.client.GetFirewallID(state.Id.ValueString())
+	firewall, err := r.client.GetFirewallID(ctx, state.Id.ValueString())
 	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Firewall %s not found, removing it from the state", state.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Clouding Client Error", fmt.Sprintf("Unable to read firewall id, got error: %s", err))
 		return
 	}
 	state.Name = types.StringValue(firewall.Name)
 	state.Description = types.StringValue(firewall.Description)
+	// Rules are only read when they are managed inline, so out-of-band rules show as drift
+	if state.Rules != nil {
+		state.Rules = firewallInlineRules(firewall.Rules)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -162,14 +264,21 @@ func (r *FirewallResource) Update(ctx context.Context, req resource.UpdateReques
 		NewName:        plan.Name.ValueString(),
 		NewDescription: plan.Description.ValueString(),
 	}
-	err := r.client.UpdateFirewall(plan.Id.ValueString(), firewall)
+	err := r.client.UpdateFirewall(ctx, plan.Id.ValueString(), firewall)
 	if err != nil {
 		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to update firewall, got error: %s", err))
 		return
 	}
 
+	if plan.Rules != nil {
+		r.syncRules(ctx, plan.Id.ValueString(), plan.Rules, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Fetch the updated Firewall from the Clouding API
-	firewall, err = r.client.GetFirewallID(plan.Id.ValueString())
+	firewall, err = r.client.GetFirewallID(ctx, plan.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to read firewall id, got error: %s", err))
 		return
@@ -197,13 +306,126 @@ func (r *FirewallResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.DeleteFirewall(state.Id.ValueString())
+	err := r.client.DeleteFirewall(ctx, state.Id.ValueString())
 	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Firewall %s already deleted", state.Id.ValueString()))
+			return
+		}
 		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to delete firewall, got error: %s", err))
 		return
 	}
 }
 
+// syncRules makes the rules of the firewall match the desired rules. Missing rules are
+// created and disabled ones are enabled before the extra ones are deleted, so allowed
+// traffic is never interrupted.
+func (r *FirewallResource) syncRules(ctx context.Context, id string, desired []FirewallInlineRuleModel, diags *diag.Diagnostics) {
+	firewall, err := r.client.GetFirewallID(ctx, id)
+	if err != nil {
+		diags.AddError("Clouding API Error", fmt.Sprintf("Unable to read firewall rules, got error: %s", err))
+		return
+	}
+
+	wanted := make(map[firewallRuleKey]bool, len(desired))
+	for _, rule := range desired {
+		wanted[rule.key()] = true
+	}
+
+	// One rule is kept for every declared rule, preferring an enabled one
+	kept := make(map[firewallRuleKey]clouding.FirewallRule, len(desired))
+	for _, rule := range firewall.Rules {
+		key := firewallRuleKeyOf(rule)
+		if !wanted[key] {
+			continue
+		}
+		if current, ok := kept[key]; !ok || (!current.Enabled && rule.Enabled) {
+			kept[key] = rule
+		}
+	}
+
+	for _, rule := range desired {
+		current, ok := kept[rule.key()]
+		if ok {
+			if current.Enabled {
+				continue
+			}
+			// The rule was disabled outside of Terraform
+			err = r.client.EnableFirewallRule(ctx, current.ID)
+			if err != nil {
+				diags.AddError("Clouding API Error", fmt.Sprintf("Unable to enable firewall rule %s, got error: %s", current.ID, err))
+				return
+			}
+			current.Enabled = true
+			kept[rule.key()] = current
+			tflog.Trace(ctx, fmt.Sprintf("Firewall rule %s enabled", current.ID))
+			continue
+		}
+
+		firewallRule := clouding.FirewallRuleID{
+			FirewallID: id,
+			FirewallRule: clouding.FirewallRule{
+				SourceIP:     rule.SourceIP.ValueString(),
+				Protocol:     rule.Protocol.ValueString(),
+				Description:  rule.Description.ValueString(),
+				PortRangeMin: rule.PortRangeMin.ValueInt64(),
+				PortRangeMax: rule.PortRangeMax.ValueInt64(),
+				Enabled:      true,
+			},
+		}
+		err = r.client.CreateFirewallRule(ctx, &firewallRule)
+		if err != nil {
+			diags.AddError("Clouding API Error", fmt.Sprintf("Unable to create firewall rule %q, got error: %s", rule.Description.ValueString(), err))
+			return
+		}
+		kept[rule.key()] = firewallRule.FirewallRule
+		tflog.Trace(ctx, fmt.Sprintf("Firewall rule %s created", firewallRule.FirewallRule.ID))
+	}
+
+	// Rules that are not declared are deleted, as well as duplicates of the declared ones
+	for _, rule := range firewall.Rules {
+		if kept[firewallRuleKeyOf(rule)].ID == rule.ID {
+			continue
+		}
+		err = r.client.DeleteFirewallRule(ctx, rule.ID)
+		if err != nil && !clouding.IsNotFound(err) {
+			diags.AddError("Clouding API Error", fmt.Sprintf("Unable to delete firewall rule %s, got error: %s", rule.ID, err))
+			return
+		}
+		tflog.Trace(ctx, fmt.Sprintf("Firewall rule %s deleted", rule.ID))
+	}
+}
+
+// firewallInlineRules converts the enabled rules of a firewall to the rule attribute, a
+// disabled rule is left out so it shows as drift. A port of 0 means the rule has no port
+// range, it is kept null as in the configuration.
+func firewallInlineRules(rules []clouding.FirewallRule) []FirewallInlineRuleModel {
+	models := make([]FirewallInlineRuleModel, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		model := FirewallInlineRuleModel{
+			SourceIP:     types.StringValue(rule.SourceIP),
+			Protocol:     types.StringValue(rule.Protocol),
+			Description:  types.StringValue(rule.Description),
+			PortRangeMin: types.Int64Null(),
+			PortRangeMax: types.Int64Null(),
+		}
+		if rule.PortRangeMin != 0 {
+			model.PortRangeMin = types.Int64Value(rule.PortRangeMin)
+		}
+		if rule.PortRangeMax != 0 {
+			model.PortRangeMax = types.Int64Value(rule.PortRangeMax)
+		}
+		models = append(models, model)
+	}
+	return models
+}
+
+// ImportState imports a firewall with its rules managed inline, an empty rule set makes
+// Read fill them in.
 func (r *FirewallResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rule"), []FirewallInlineRuleModel{})...)
 }
@@ -1,13 +1,22 @@
 package provider_test
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"sort"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccFirewallResource(t *testing.T) {
@@ -51,3 +60,263 @@ resource "clouding_firewall" "test" {
 }
 `, name, description)
 }
+
+func TestFirewallResourceNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := &provider.FirewallResource{}
+	testConfigureResource(t, r, testNotFoundHandler(t))
+	state := testResourceState(t, r, &provider.FirewallResourceModel{
+		Id:          types.StringValue("ZPlL0kxDYQ9Q3Yb5"),
+		Name:        types.StringValue("firewall-one"),
+		Description: types.StringValue("testacc description one"),
+	})
+
+	readResp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull())
+
+	deleteResp := fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, &deleteResp)
+	assert.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+}
+
+// testFirewallRulesHandler answers as the Clouding API with the firewall ZPlL0kxDYQ9Q3Yb5
+// holding an ssh, an out-of-band http and a disabled smtp rule, and records the rules created
+// and the requests changing the existing ones.
+func testFirewallRulesHandler(t *testing.T, mu *sync.Mutex, created, changed *[]string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/firewalls/ZPlL0kxDYQ9Q3Yb5", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{
+		  "id": "ZPlL0kxDYQ9Q3Yb5",
+		  "name": "firewall-one",
+		  "description": "testacc description one",
+		  "rules": [
+		    {"id": "rXqQ8D1NyZ5Kj3Bv", "sourceIp": "0.0.0.0/0", "protocol": "tcp", "description": "ssh", "portRangeMin": 22, "portRangeMax": 22, "enabled": true},
+		    {"id": "mO4vN7zLkA2Wq9Ye", "sourceIp": "0.0.0.0/0", "protocol": "tcp", "description": "http", "portRangeMin": 80, "portRangeMax": 80, "enabled": true},
+		    {"id": "Jd5hW2sXcR8Ub4Fo", "sourceIp": "0.0.0.0/0", "protocol": "tcp", "description": "smtp", "portRangeMin": 25, "portRangeMax": 25, "enabled": false}
+		  ]
+		}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	})
+	mux.HandleFunc("/v1/firewalls/ZPlL0kxDYQ9Q3Yb5/rules", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*created = append(*created, r.Method)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, err := w.Write([]byte(`{"id": "b8RkPz3LmQ6Tn1Vc", "sourceIp": "10.0.0.0/8", "protocol": "icmp", "description": "ping", "enabled": true}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	})
+	mux.HandleFunc("/v1/firewalls/rules/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*changed = append(*changed, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.Handle("/", testNotFoundHandler(t))
+	return mux
+}
+
+func testFirewallModel(rules []provider.FirewallInlineRuleModel) *provider.FirewallResourceModel {
+	return &provider.FirewallResourceModel{
+		Id:          types.StringValue("ZPlL0kxDYQ9Q3Yb5"),
+		Name:        types.StringValue("firewall-one"),
+		Description: types.StringValue("testacc description one"),
+		Rules:       rules,
+	}
+}
+
+func TestFirewallResourceUpdateRules(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	var created, changed []string
+	r := &provider.FirewallResource{}
+	testConfigureResource(t, r, testFirewallRulesHandler(t, &mu, &created, &changed))
+
+	state := testResourceState(t, r, testFirewallModel([]provider.FirewallInlineRuleModel{}))
+	plan := testResourcePlan(t, r, testFirewallModel([]provider.FirewallInlineRuleModel{
+		{
+			SourceIP:     types.StringValue("0.0.0.0/0"),
+			Protocol:     types.StringValue("tcp"),
+			Description:  types.StringValue("ssh"),
+			PortRangeMin: types.Int64Value(22),
+			PortRangeMax: types.Int64Value(22),
+		},
+		{
+			SourceIP:     types.StringValue("10.0.0.0/8"),
+			Protocol:     types.StringValue("icmp"),
+			Description:  types.StringValue("ping"),
+			PortRangeMin: types.Int64Null(),
+			PortRangeMax: types.Int64Null(),
+		},
+	}))
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	// Only the ping rule is missing and the http and smtp rules are not declared
+	assert.Equal(t, []string{http.MethodPost}, created)
+	assert.Equal(t, []string{"DELETE /v1/firewalls/rules/mO4vN7zLkA2Wq9Ye", "DELETE /v1/firewalls/rules/Jd5hW2sXcR8Ub4Fo"}, changed)
+}
+
+func TestFirewallResourceUpdateDisabledRule(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	var created, changed []string
+	r := &provider.FirewallResource{}
+	testConfigureResource(t, r, testFirewallRulesHandler(t, &mu, &created, &changed))
+
+	rules := []provider.FirewallInlineRuleModel{}
+	for _, rule := range []struct {
+		description string
+		port        int64
+	}{{"ssh", 22}, {"http", 80}, {"smtp", 25}} {
+		rules = append(rules, provider.FirewallInlineRuleModel{
+			SourceIP:     types.StringValue("0.0.0.0/0"),
+			Protocol:     types.StringValue("tcp"),
+			Description:  types.StringValue(rule.description),
+			PortRangeMin: types.Int64Value(rule.port),
+			PortRangeMax: types.Int64Value(rule.port),
+		})
+	}
+	state := testResourceState(t, r, testFirewallModel(rules[:2]))
+	plan := testResourcePlan(t, r, testFirewallModel(rules))
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	// The disabled smtp rule is enabled in place
+	assert.Empty(t, created)
+	assert.Equal(t, []string{"POST /v1/firewalls/rules/Jd5hW2sXcR8Ub4Fo/enable"}, changed)
+}
+
+func TestFirewallResourceCreateRuleError(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	var created, changed []string
+	mux := http.NewServeMux()
+	testHandleJSON(t, mux, "/v1/firewalls", http.StatusCreated, `{"id": "ZPlL0kxDYQ9Q3Yb5", "name": "firewall-one", "description": "testacc description one"}`)
+	testHandleJSON(t, mux, "/v1/firewalls/ZPlL0kxDYQ9Q3Yb5/rules", http.StatusUnprocessableEntity, `{"title": "Unprocessable Entity", "detail": "invalid source ip"}`)
+	mux.Handle("/", testFirewallRulesHandler(t, &mu, &created, &changed))
+	r := &provider.FirewallResource{}
+	testConfigureResource(t, r, mux)
+
+	model := testFirewallModel([]provider.FirewallInlineRuleModel{
+		{
+			SourceIP:     types.StringValue("10.0.0.0/8"),
+			Protocol:     types.StringValue("icmp"),
+			Description:  types.StringValue("ping"),
+			PortRangeMin: types.Int64Null(),
+			PortRangeMax: types.Int64Null(),
+		},
+	})
+	model.Id = types.StringUnknown()
+	model.LastUpdated = types.StringUnknown()
+	plan := testResourcePlan(t, r, model)
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+	assert.True(t, resp.Diagnostics.HasError())
+
+	// The state holds the rules of the firewall instead of the planned ones
+	var state provider.FirewallResourceModel
+	resp.State.Get(ctx, &state)
+	assert.Equal(t, "ZPlL0kxDYQ9Q3Yb5", state.Id.ValueString())
+	descriptions := make([]string, 0, len(state.Rules))
+	for _, rule := range state.Rules {
+		descriptions = append(descriptions, rule.Description.ValueString())
+	}
+	sort.Strings(descriptions)
+	assert.Equal(t, []string{"http", "ssh"}, descriptions)
+}
+
+func TestFirewallResourceReadRules(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		rules    []provider.FirewallInlineRuleModel
+		expected []string
+	}{
+		"managed inline": {
+			rules:    []provider.FirewallInlineRuleModel{},
+			expected: []string{"http", "ssh"},
+		},
+		"not managed": {
+			rules: nil,
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var mu sync.Mutex
+			var created, changed []string
+			r := &provider.FirewallResource{}
+			testConfigureResource(t, r, testFirewallRulesHandler(t, &mu, &created, &changed))
+			state := testResourceState(t, r, testFirewallModel(test.rules))
+
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var model provider.FirewallResourceModel
+			resp.State.Get(ctx, &model)
+			if test.expected == nil {
+				assert.Nil(t, model.Rules)
+				return
+			}
+			descriptions := make([]string, 0, len(model.Rules))
+			for _, rule := range model.Rules {
+				descriptions = append(descriptions, rule.Description.ValueString())
+			}
+			sort.Strings(descriptions)
+			assert.Equal(t, test.expected, descriptions)
+		})
+	}
+}
+
+func TestFirewallResourceImportState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	var created, changed []string
+	r := &provider.FirewallResource{}
+	testConfigureResource(t, r, testFirewallRulesHandler(t, &mu, &created, &changed))
+	state := testResourceState(t, r, testFirewallModel(nil))
+	state.Raw = tftypes.NewValue(state.Raw.Type(), nil)
+
+	importResp := fwresource.ImportStateResponse{State: state}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "ZPlL0kxDYQ9Q3Yb5"}, &importResp)
+	assert.False(t, importResp.Diagnostics.HasError(), importResp.Diagnostics)
+
+	readResp := fwresource.ReadResponse{State: importResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: importResp.State}, &readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	var model provider.FirewallResourceModel
+	readResp.State.Get(ctx, &model)
+	assert.Equal(t, "firewall-one", model.Name.ValueString())
+	descriptions := make([]string, 0, len(model.Rules))
+	for _, rule := range model.Rules {
+		descriptions = append(descriptions, rule.Description.ValueString())
+	}
+	sort.Strings(descriptions)
+	assert.Equal(t, []string{"http", "ssh"}, descriptions)
+}
@@ -5,10 +5,11 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -20,6 +21,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &FirewallRuleResource{}
 var _ resource.ResourceWithImportState = &FirewallRuleResource{}
+var _ resource.ResourceWithModifyPlan = &FirewallRuleResource{}
 
 func NewFirewallRuleResource() resource.Resource {
 	return &FirewallRuleResource{}
@@ -39,6 +41,7 @@ type FirewallRuleResourceModel struct {
 	Description  types.String `tfsdk:"description"`
 	PortRangeMin types.Int64  `tfsdk:"port_range_min"`
 	PortRangeMax types.Int64  `tfsdk:"port_range_max"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
 }
 
 func (r *FirewallRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -48,7 +51,9 @@ func (r *FirewallRuleResource) Metadata(ctx context.Context, req resource.Metada
 func (r *FirewallRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language Firewall.
-		MarkdownDescription: "The create firewall rule resource allows you to create a new firewall rule to allow or block incoming traffic based on a set of conditions.",
+		MarkdownDescription: "The create firewall rule resource allows you to create a new firewall rule to allow or block incoming traffic based on a set of conditions. " +
+			"The description and enabled state are updated in place. A new source_ip, protocol or port range creates a new rule before the old one is deleted, leaving no window without the rule, and changes its id. " +
+			"A new firewall_id replaces the rule, use `lifecycle { create_before_destroy = true }` to create the new rule first in that case too.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -68,47 +73,58 @@ func (r *FirewallRuleResource) Schema(ctx context.Context, req resource.SchemaRe
 			"source_ip": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The IP or CIDR that the rule will be applied for.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"protocol": schema.StringAttribute{
 				Required: true,
 				MarkdownDescription: `A firewall rule protocol is a set of rules and procedures that determine how a firewall handles network traffic.` +
 					`Supported protocols are: ah,dccp,egp,esp,gre,hopopt,icmp,igmp,ip,ipip,ospf,pgm,rsvp,sctp,tcp,udp,udplite,vrrp, or any number between 0 and 255 represented as a string.`,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "A short description of the rule.",
+				MarkdownDescription: "A short description of the rule. It can be changed without replacing the rule.",
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 					stringvalidator.LengthAtMost(512),
 				},
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"port_range_min": schema.Int64Attribute{
 				Optional:            true,
 				MarkdownDescription: "The minimum port of the port range.",
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
-				},
 			},
 			"port_range_max": schema.Int64Attribute{
 				Optional:            true,
 				MarkdownDescription: "The maximum port of the port range.",
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
-				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				MarkdownDescription: "Default: true" +
+					"If false, the rule is disabled and does not apply, without deleting it. It can be changed without replacing the rule.",
 			},
 		},
 	}
 }
 
+// ModifyPlan plans a new id when the rule is recreated by Update.
+func (r *FirewallRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is recreated when the rule is created or destroyed
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state FirewallRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if firewallRuleRecreated(plan, state) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
+
 func (r *FirewallRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -138,31 +154,11 @@ func (r *FirewallRuleResource) Create(ctx context.Context, req resource.CreateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	firewallRule := clouding.FirewallRuleID{
-		FirewallID: plan.FirewallID.ValueString(),
-		FirewallRule: clouding.FirewallRule{
-			SourceIP:     plan.SourceIP.ValueString(),
-			Protocol:     plan.Protocol.ValueString(),
-			Description:  plan.Description.ValueString(),
-			PortRangeMin: plan.PortRangeMin.ValueInt64(),
-			PortRangeMax: plan.PortRangeMax.ValueInt64(),
-		},
-	}
-	err := r.client.CreateFirewallRule(&firewallRule)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create example, got error: %s", err))
+	r.createRule(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Save into the Terraform state.
-	plan.Id = types.StringValue(firewallRule.FirewallRule.ID)
-	plan.FirewallID = types.StringValue(firewallRule.FirewallID)
-	plan.SourceIP = types.StringValue(firewallRule.FirewallRule.SourceIP)
-	plan.Protocol = types.StringValue(firewallRule.FirewallRule.Protocol)
-	plan.Description = types.StringValue(firewallRule.FirewallRule.Description)
-	plan.PortRangeMin = types.Int64Value(firewallRule.FirewallRule.PortRangeMin)
-	plan.PortRangeMax = types.Int64Value(firewallRule.FirewallRule.PortRangeMax)
-
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "FirewallRule resource created")
@@ -184,8 +180,13 @@ func (r *FirewallRuleResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	firewallRule, err := r.client.GetFirewallRule(state.Id.ValueString())
+	firewallRule, err := r.client.GetFirewallRule(ctx, state.Id.ValueString())
 	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Firewall rule %s not found, removing it from the state", state.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Clouding Client Error", fmt.Sprintf("Unable to read firewall id, got error: %s", err))
 		return
 	}
@@ -197,6 +198,7 @@ func (r *FirewallRuleResource) Read(ctx context.Context, req resource.ReadReques
 	state.Description = types.StringValue(firewallRule.FirewallRule.Description)
 	state.PortRangeMin = types.Int64Value(firewallRule.FirewallRule.PortRangeMin)
 	state.PortRangeMax = types.Int64Value(firewallRule.FirewallRule.PortRangeMax)
+	state.Enabled = types.BoolValue(firewallRule.FirewallRule.Enabled)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -205,16 +207,53 @@ func (r *FirewallRuleResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (r *FirewallRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan FirewallResourceModel
+	var plan, state FirewallRuleResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Update FirewallRule on the Clouding API
+	// A rule cannot change its source, protocol or ports, the new rule is created before the old
+	// one is deleted so the traffic it allows is never blocked
+	if firewallRuleRecreated(plan, state) {
+		r.createRule(ctx, &plan, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		err := r.client.DeleteFirewallRule(ctx, state.Id.ValueString())
+		if err != nil && !clouding.IsNotFound(err) {
+			// Save the new rule, the old one is left to be deleted by hand
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to delete the replaced firewall rule %s, got error: %s", state.Id.ValueString(), err))
+			return
+		}
+		tflog.Trace(ctx, fmt.Sprintf("Firewall rule %s replaced by %s", state.Id.ValueString(), plan.Id.ValueString()))
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	// Update FirewallRule on the Clouding API, only the description and enabled state can change in place
+	if !plan.Description.Equal(state.Description) {
+		err := r.client.UpdateFirewallRule(ctx, plan.Id.ValueString(), plan.Description.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to update firewall rule, got error: %s", err))
+			return
+		}
+	}
+
+	if !plan.Enabled.Equal(state.Enabled) {
+		err := r.setEnabled(ctx, plan.Id.ValueString(), plan.Enabled.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to change firewall rule enabled state, got error: %s", err))
+			return
+		}
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -233,13 +272,74 @@ func (r *FirewallRuleResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	err := r.client.DeleteFirewallRule(state.Id.ValueString())
+	err := r.client.DeleteFirewallRule(ctx, state.Id.ValueString())
 	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Firewall rule %s already deleted", state.Id.ValueString()))
+			return
+		}
 		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to delete firewall rule, got error: %s", err))
 		return
 	}
 }
 
+// createRule creates the planned rule with its enabled state and fills the plan with the
+// values of the new rule.
+func (r *FirewallRuleResource) createRule(ctx context.Context, plan *FirewallRuleResourceModel, diags *diag.Diagnostics) {
+	firewallRule := clouding.FirewallRuleID{
+		FirewallID: plan.FirewallID.ValueString(),
+		FirewallRule: clouding.FirewallRule{
+			SourceIP:     plan.SourceIP.ValueString(),
+			Protocol:     plan.Protocol.ValueString(),
+			Description:  plan.Description.ValueString(),
+			PortRangeMin: plan.PortRangeMin.ValueInt64(),
+			PortRangeMax: plan.PortRangeMax.ValueInt64(),
+			Enabled:      plan.Enabled.ValueBool(),
+		},
+	}
+	err := r.client.CreateFirewallRule(ctx, &firewallRule)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create firewall rule, got error: %s", err))
+		return
+	}
+
+	// Make sure the new rule has the planned state, whatever the API defaults to
+	if firewallRule.FirewallRule.Enabled != plan.Enabled.ValueBool() {
+		err = r.setEnabled(ctx, firewallRule.FirewallRule.ID, plan.Enabled.ValueBool())
+		if err != nil {
+			diags.AddError("Clouding API Error", fmt.Sprintf("Unable to change firewall rule enabled state, got error: %s", err))
+			return
+		}
+		firewallRule.FirewallRule.Enabled = plan.Enabled.ValueBool()
+	}
+
+	plan.Id = types.StringValue(firewallRule.FirewallRule.ID)
+	plan.FirewallID = types.StringValue(firewallRule.FirewallID)
+	plan.SourceIP = types.StringValue(firewallRule.FirewallRule.SourceIP)
+	plan.Protocol = types.StringValue(firewallRule.FirewallRule.Protocol)
+	plan.Description = types.StringValue(firewallRule.FirewallRule.Description)
+	plan.PortRangeMin = types.Int64Value(firewallRule.FirewallRule.PortRangeMin)
+	plan.PortRangeMax = types.Int64Value(firewallRule.FirewallRule.PortRangeMax)
+	plan.Enabled = types.BoolValue(firewallRule.FirewallRule.Enabled)
+}
+
+// firewallRuleRecreated reports whether the update of a rule needs a new rule, only the
+// description and enabled state can change in place.
+func firewallRuleRecreated(plan, state FirewallRuleResourceModel) bool {
+	return !plan.SourceIP.Equal(state.SourceIP) ||
+		!plan.Protocol.Equal(state.Protocol) ||
+		!plan.PortRangeMin.Equal(state.PortRangeMin) ||
+		!plan.PortRangeMax.Equal(state.PortRangeMax)
+}
+
+// setEnabled enables or disables the firewall rule.
+func (r *FirewallRuleResource) setEnabled(ctx context.Context, id string, enabled bool) error {
+	if enabled {
+		return r.client.EnableFirewallRule(ctx, id)
+	}
+	return r.client.DisableFirewallRule(ctx, id)
+}
+
 func (r *FirewallRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
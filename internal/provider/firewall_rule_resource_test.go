@@ -1,10 +1,21 @@
 package provider_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccFirewallRuleResource(t *testing.T) {
@@ -58,3 +69,247 @@ resource "clouding_firewall_rule" "test" {
 }
 `, source_ip, protocol, description, portMin, portMax)
 }
+
+func TestFirewallRuleResourceNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := &provider.FirewallRuleResource{}
+	testConfigureResource(t, r, testNotFoundHandler(t))
+	state := testResourceState(t, r, &provider.FirewallRuleResourceModel{
+		Id:          types.StringValue("eAMVoaXqP9BLJwR6"),
+		FirewallID:  types.StringValue("ZPlL0kxDYQ9Q3Yb5"),
+		SourceIP:    types.StringValue("0.0.0.0/0"),
+		Protocol:    types.StringValue("tcp"),
+		Description: types.StringValue("Allow http connections"),
+	})
+
+	readResp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull())
+
+	deleteResp := fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, &deleteResp)
+	assert.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+}
+
+// testFirewallRuleHandler answers as the Clouding API for the rule eAMVoaXqP9BLJwR6 of the
+// firewall ZPlL0kxDYQ9Q3Yb5, deletes the rule Xb7nQ2LzK5mVr9Tw it replaces and records every
+// request made.
+func testFirewallRuleHandler(t *testing.T, mu *sync.Mutex, requests *[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+
+		switch r.URL.Path {
+		case "/v1/firewalls/rules/eAMVoaXqP9BLJwR6":
+			// Only the description is sent, the enabled state has its own endpoints
+			var body map[string]any
+			err := json.NewDecoder(r.Body).Decode(&body)
+			if err != nil {
+				t.Errorf("error decoding request: %s", err)
+			}
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Len(t, body, 1)
+			assert.Contains(t, body, "description")
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1/firewalls/ZPlL0kxDYQ9Q3Yb5/rules":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, err := w.Write([]byte(`{"id": "eAMVoaXqP9BLJwR6", "sourceIp": "0.0.0.0/0", "protocol": "tcp", "description": "ssh", "portRangeMin": 22, "portRangeMax": 22, "enabled": true}`))
+			if err != nil {
+				t.Errorf("error writing response: %s", err)
+			}
+		case "/v1/firewalls/rules/eAMVoaXqP9BLJwR6/enable", "/v1/firewalls/rules/eAMVoaXqP9BLJwR6/disable":
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1/firewalls/rules/Xb7nQ2LzK5mVr9Tw":
+			assert.Equal(t, http.MethodDelete, r.Method)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			testNotFoundHandler(t).ServeHTTP(w, r)
+		}
+	})
+}
+
+func testFirewallRuleModel(enabled bool) *provider.FirewallRuleResourceModel {
+	return &provider.FirewallRuleResourceModel{
+		Id:           types.StringValue("eAMVoaXqP9BLJwR6"),
+		FirewallID:   types.StringValue("ZPlL0kxDYQ9Q3Yb5"),
+		SourceIP:     types.StringValue("0.0.0.0/0"),
+		Protocol:     types.StringValue("tcp"),
+		Description:  types.StringValue("ssh"),
+		PortRangeMin: types.Int64Value(22),
+		PortRangeMax: types.Int64Value(22),
+		Enabled:      types.BoolValue(enabled),
+	}
+}
+
+func TestFirewallRuleResourceCreateDisabled(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	var requests []string
+	r := &provider.FirewallRuleResource{}
+	testConfigureResource(t, r, testFirewallRuleHandler(t, &mu, &requests))
+
+	model := testFirewallRuleModel(false)
+	model.Id = types.StringUnknown()
+	plan := testResourcePlan(t, r, model)
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, []string{
+		"POST /v1/firewalls/ZPlL0kxDYQ9Q3Yb5/rules",
+		"POST /v1/firewalls/rules/eAMVoaXqP9BLJwR6/disable",
+	}, requests)
+
+	var state provider.FirewallRuleResourceModel
+	resp.State.Get(ctx, &state)
+	assert.False(t, state.Enabled.ValueBool())
+}
+
+func TestFirewallRuleResourceUpdate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		state       bool
+		plan        bool
+		description string
+		requests    []string
+	}{
+		"disable": {
+			state:       true,
+			plan:        false,
+			description: "ssh",
+			requests:    []string{"POST /v1/firewalls/rules/eAMVoaXqP9BLJwR6/disable"},
+		},
+		"enable": {
+			state:       false,
+			plan:        true,
+			description: "ssh",
+			requests:    []string{"POST /v1/firewalls/rules/eAMVoaXqP9BLJwR6/enable"},
+		},
+		"description": {
+			state:       true,
+			plan:        true,
+			description: "Allow ssh connections",
+			requests:    []string{"PATCH /v1/firewalls/rules/eAMVoaXqP9BLJwR6"},
+		},
+		"description and disable": {
+			state:       true,
+			plan:        false,
+			description: "Allow ssh connections",
+			requests: []string{
+				"PATCH /v1/firewalls/rules/eAMVoaXqP9BLJwR6",
+				"POST /v1/firewalls/rules/eAMVoaXqP9BLJwR6/disable",
+			},
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var mu sync.Mutex
+			var requests []string
+			r := &provider.FirewallRuleResource{}
+			testConfigureResource(t, r, testFirewallRuleHandler(t, &mu, &requests))
+			state := testResourceState(t, r, testFirewallRuleModel(test.state))
+			model := testFirewallRuleModel(test.plan)
+			model.Description = types.StringValue(test.description)
+			plan := testResourcePlan(t, r, model)
+
+			resp := fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, test.requests, requests)
+
+			var updated provider.FirewallRuleResourceModel
+			resp.State.Get(ctx, &updated)
+			assert.Equal(t, test.plan, updated.Enabled.ValueBool())
+			assert.Equal(t, test.description, updated.Description.ValueString())
+		})
+	}
+}
+
+func TestFirewallRuleResourceUpdateRecreate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	var requests []string
+	r := &provider.FirewallRuleResource{}
+	testConfigureResource(t, r, testFirewallRuleHandler(t, &mu, &requests))
+
+	model := testFirewallRuleModel(true)
+	model.Id = types.StringValue("Xb7nQ2LzK5mVr9Tw")
+	model.SourceIP = types.StringValue("10.0.0.0/8")
+	state := testResourceState(t, r, model)
+	model = testFirewallRuleModel(true)
+	model.Id = types.StringUnknown()
+	plan := testResourcePlan(t, r, model)
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	// The new rule is created before the old one is deleted
+	assert.Equal(t, []string{
+		"POST /v1/firewalls/ZPlL0kxDYQ9Q3Yb5/rules",
+		"DELETE /v1/firewalls/rules/Xb7nQ2LzK5mVr9Tw",
+	}, requests)
+
+	var updated provider.FirewallRuleResourceModel
+	resp.State.Get(ctx, &updated)
+	assert.Equal(t, "eAMVoaXqP9BLJwR6", updated.Id.ValueString())
+	assert.Equal(t, "0.0.0.0/0", updated.SourceIP.ValueString())
+}
+
+func TestFirewallRuleResourceModifyPlanRecreate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		update    func(*provider.FirewallRuleResourceModel)
+		recreated bool
+	}{
+		"in place": {
+			update: func(model *provider.FirewallRuleResourceModel) {
+				model.Description = types.StringValue("Allow ssh connections")
+				model.Enabled = types.BoolValue(false)
+			},
+		},
+		"source ip": {
+			update: func(model *provider.FirewallRuleResourceModel) {
+				model.SourceIP = types.StringValue("10.0.0.0/8")
+			},
+			recreated: true,
+		},
+		"port range": {
+			update: func(model *provider.FirewallRuleResourceModel) {
+				model.PortRangeMin = types.Int64Value(2222)
+				model.PortRangeMax = types.Int64Value(2222)
+			},
+			recreated: true,
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := &provider.FirewallRuleResource{}
+			state := testResourceState(t, r, testFirewallRuleModel(true))
+			model := testFirewallRuleModel(true)
+			test.update(model)
+			plan := testResourcePlan(t, r, model)
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Zero(t, resp.Diagnostics.WarningsCount(), resp.Diagnostics)
+
+			var id types.String
+			resp.Plan.GetAttribute(ctx, path.Root("id"), &id)
+			assert.Equal(t, test.recreated, id.IsUnknown())
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &FlavorsDataSource{}
+
+func NewFlavorsDataSource() datasource.DataSource {
+	return &FlavorsDataSource{}
+}
+
+// FlavorsDataSource defines the data source implementation.
+type FlavorsDataSource struct {
+	client *clouding.API
+}
+
+// FlavorsDataSourceModel describes the data source data model.
+type FlavorsDataSourceModel struct {
+	Flavors []FlavorModel `tfsdk:"flavors"`
+}
+
+type FlavorModel struct {
+	Id                  types.String  `tfsdk:"id"`
+	Vcores              types.Float64 `tfsdk:"vcores"`
+	RamGb               types.Int64   `tfsdk:"ram_gb"`
+	PricePerHour        types.Float64 `tfsdk:"price_per_hour"`
+	PricePerMonthApprox types.Float64 `tfsdk:"price_per_month_approx"`
+}
+
+func (d *FlavorsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flavors"
+}
+
+func (d *FlavorsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Flavors data source lists the available flavor sizes. Flavors are pre-defined configurations of CPU and RAM used by the `flavor_id` of a server.",
+
+		Attributes: map[string]schema.Attribute{
+			"flavors": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The list of available flavors.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: `The identifier of the flavor, e.g. "0.5x1" means half a virtual core and 1 gigabyte of RAM.`,
+						},
+						"vcores": schema.Float64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of virtual cores of the flavor.",
+						},
+						"ram_gb": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The amount of RAM in GB of the flavor.",
+						},
+						"price_per_hour": schema.Float64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The price per hour of the flavor.",
+						},
+						"price_per_month_approx": schema.Float64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The approximate price per month of the flavor.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *FlavorsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clouding.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clouding.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *FlavorsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state FlavorsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flavors, err := d.client.ListFlavors(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Flavors",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Flavors = make([]FlavorModel, 0, len(flavors))
+	for _, flavor := range flavors {
+		state.Flavors = append(state.Flavors, FlavorModel{
+			Id:                  types.StringValue(flavor.ID),
+			Vcores:              types.Float64Value(flavor.VCores),
+			RamGb:               types.Int64Value(flavor.RamGb),
+			PricePerHour:        types.Float64Value(flavor.PricePerHour),
+			PricePerMonthApprox: types.Float64Value(flavor.PricePerMonthApprox),
+		})
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("read flavors data source, %d flavors found", len(state.Flavors)))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,30 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlavorsDataSource(t *testing.T) {
+	t.Parallel()
+	requests := 0
+	d := &provider.FlavorsDataSource{}
+	testConfigureDataSource(t, d, testFlavorsHandler(t, &requests))
+
+	resp := testReadDataSource(t, d, &provider.FlavorsDataSourceModel{})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state provider.FlavorsDataSourceModel
+	diags := resp.State.Get(context.Background(), &state)
+	assert.False(t, diags.HasError(), diags)
+
+	assert.Len(t, state.Flavors, 2)
+	assert.Equal(t, "0.5x1", state.Flavors[0].Id.ValueString())
+	assert.Equal(t, 0.5, state.Flavors[0].Vcores.ValueFloat64())
+	assert.Equal(t, int64(2), state.Flavors[1].RamGb.ValueInt64())
+	assert.Equal(t, 0.0137, state.Flavors[1].PricePerHour.ValueFloat64())
+	assert.Equal(t, float64(10), state.Flavors[1].PricePerMonthApprox.ValueFloat64())
+}
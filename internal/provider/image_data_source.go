@@ -3,9 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
@@ -27,6 +33,8 @@ type ImageDataSource struct {
 type ImageDataSourceModel struct {
 	Id                  types.String             `tfsdk:"id"`
 	Name                types.String             `tfsdk:"name"`
+	NameRegex           types.String             `tfsdk:"name_regex"`
+	MostRecent          types.Bool               `tfsdk:"most_recent"`
 	MinimumSizeGb       types.Int64              `tfsdk:"minimum_size_gb"`
 	AccessMethods       *ImageAccessMethodsModel `tfsdk:"access_methods"`
 	PricePerHour        types.Float64            `tfsdk:"price_per_hour"`
@@ -39,6 +47,13 @@ type ImageAccessMethodsModel struct {
 	Password types.String `tfsdk:"password"`
 }
 
+func newImageAccessMethodsModel(accessMethods clouding.ImageAccessMethod) *ImageAccessMethodsModel {
+	return &ImageAccessMethodsModel{
+		SshKey:   types.StringValue(accessMethods.SshKey),
+		Password: types.StringValue(accessMethods.Password),
+	}
+}
+
 func (d *ImageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_image"
 }
@@ -46,17 +61,30 @@ func (d *ImageDataSource) Metadata(ctx context.Context, req datasource.MetadataR
 func (d *ImageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language Image.
-		MarkdownDescription: "Image data source allows retrieving a specific image and its access methods by providing the image's unique identifier.",
+		MarkdownDescription: "Image data source allows retrieving a specific image and its access methods by providing the image's unique identifier, its name or a regular expression matching its name.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "A unique string identifier used to reference a Image.",
-				Required:            true,
+				MarkdownDescription: "A unique string identifier used to reference a Image. Exactly one of `id`, `name` or `name_regex` must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("name_regex")),
+				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the image.",
+				MarkdownDescription: "The name of the image. When it is set, the image with exactly this name is returned.",
+				Optional:            true,
 				Computed:            true,
 			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "A [RE2 regular expression](https://github.com/google/re2/wiki/Syntax) matched against the image names.",
+				Optional:            true,
+			},
+			"most_recent": schema.BoolAttribute{
+				MarkdownDescription: "Default: false" + "If more than one image matches `name` or `name_regex`, use the most recent one, the one with the highest version in its name, e.g. \"Ubuntu 22.04\" instead of \"Ubuntu 20.04\". Otherwise matching several images is an error.",
+				Optional:            true,
+			},
 			"minimum_size_gb": schema.Int64Attribute{
 				MarkdownDescription: "The minimum size in gigabytes of the image.",
 				Computed:            true,
@@ -128,7 +156,13 @@ func (d *ImageDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	image, err := d.client.GetImageID(state.Id.ValueString())
+	var image clouding.Image
+	var err error
+	if !state.Id.IsNull() {
+		image, err = d.client.GetImageID(ctx, state.Id.ValueString())
+	} else {
+		image, err = d.lookupImage(ctx, state)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving Image",
@@ -140,10 +174,7 @@ func (d *ImageDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	state.Id = types.StringValue(image.ID)
 	state.Name = types.StringValue(image.Name)
 	state.MinimumSizeGb = types.Int64Value(image.MinimumSizeGB)
-	state.AccessMethods = &ImageAccessMethodsModel{
-		SshKey:   types.StringValue(image.AccessMethods.SshKey),
-		Password: types.StringValue(image.AccessMethods.Password),
-	}
+	state.AccessMethods = newImageAccessMethodsModel(image.AccessMethods)
 	state.PricePerHour = types.Float64Value(image.PricePerHour)
 	state.PricePerMonthApprox = types.Float64Value(image.PricePerMonthApprox)
 	state.BillingUnit = types.StringValue(image.BillingUnit)
@@ -158,3 +189,51 @@ func (d *ImageDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 }
+
+// lookupImage returns the image whose name matches the name or name_regex of the configuration.
+func (d *ImageDataSource) lookupImage(ctx context.Context, state ImageDataSourceModel) (clouding.Image, error) {
+	var match func(name string) bool
+	var criteria string
+	if !state.Name.IsNull() {
+		criteria = fmt.Sprintf("name %q", state.Name.ValueString())
+		match = func(name string) bool { return name == state.Name.ValueString() }
+	} else {
+		re, err := regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			return clouding.Image{}, fmt.Errorf("name_regex %q is not a valid regular expression: %s", state.NameRegex.ValueString(), err)
+		}
+		criteria = fmt.Sprintf("name_regex %q", state.NameRegex.ValueString())
+		match = re.MatchString
+	}
+
+	images, err := d.client.ListImages(ctx)
+	if err != nil {
+		return clouding.Image{}, err
+	}
+
+	var matches []clouding.Image
+	for _, image := range images {
+		if match(image.Name) {
+			matches = append(matches, image)
+		}
+	}
+
+	switch {
+	case len(matches) == 0:
+		return clouding.Image{}, fmt.Errorf("no image found with %s", criteria)
+	case len(matches) == 1:
+		return matches[0], nil
+	case !state.MostRecent.ValueBool():
+		names := make([]string, 0, len(matches))
+		for _, image := range matches {
+			names = append(names, fmt.Sprintf("%q (%s)", image.Name, image.ID))
+		}
+		return clouding.Image{}, fmt.Errorf("%d images found with %s: %s. Use a more specific criteria or set most_recent to true", len(matches), criteria, strings.Join(names, ", "))
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return compareVersions(matches[i].Name, matches[j].Name) > 0
+	})
+	tflog.Debug(ctx, fmt.Sprintf("%d images found with %s, using the most recent %s", len(matches), criteria, matches[0].ID))
+	return matches[0], nil
+}
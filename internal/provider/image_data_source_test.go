@@ -1,9 +1,13 @@
 package provider_test
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccImageDataSource(t *testing.T) {
@@ -17,6 +21,13 @@ func TestAccImageDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.clouding_image.test", "name", "Debian 11 (64 Bit)"),
 				),
 			},
+			// Read by name testing
+			{
+				Config: testAccImageNameConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clouding_image.test", "id", "wLQbN5nvg829JaeZ"),
+				),
+			},
 		},
 	})
 }
@@ -26,3 +37,81 @@ data "clouding_image" "test" {
 	id = "wLQbN5nvg829JaeZ"
 }
 `
+
+const testAccImageNameConfig = `
+data "clouding_image" "test" {
+	name = "Debian 11 (64 Bit)"
+}
+`
+
+func TestImageDataSourceLookup(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		config provider.ImageDataSourceModel
+		want   string
+	}{
+		"name": {
+			config: provider.ImageDataSourceModel{Name: types.StringValue("Ubuntu 9.10 (64 Bit)")},
+			want:   "NAQopLWpMbxMmr32",
+		},
+		"name regex": {
+			config: provider.ImageDataSourceModel{NameRegex: types.StringValue("^Windows")},
+			want:   "d3mKbx4zd3XEQaqP",
+		},
+		"most recent": {
+			config: provider.ImageDataSourceModel{NameRegex: types.StringValue("^Ubuntu"), MostRecent: types.BoolValue(true)},
+			want:   "lo1qJ9oZb1xGMEgD",
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			d := &provider.ImageDataSource{}
+			testConfigureDataSource(t, d, testImagesHandler(t))
+
+			resp := testReadDataSource(t, d, &test.config)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var state provider.ImageDataSourceModel
+			diags := resp.State.Get(context.Background(), &state)
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, test.want, state.Id.ValueString())
+			assert.NotEmpty(t, state.Name.ValueString())
+			assert.NotNil(t, state.AccessMethods)
+		})
+	}
+}
+
+func TestImageDataSourceLookupError(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		config provider.ImageDataSourceModel
+		want   string
+	}{
+		"no match": {
+			config: provider.ImageDataSourceModel{Name: types.StringValue("Ubuntu 22.04")},
+			want:   `no image found with name "Ubuntu 22.04"`,
+		},
+		"several matches": {
+			config: provider.ImageDataSourceModel{NameRegex: types.StringValue("^Ubuntu")},
+			want:   `2 images found with name_regex "^Ubuntu": "Ubuntu 22.04 (64 Bit)" (lo1qJ9oZb1xGMEgD), "Ubuntu 9.10 (64 Bit)" (NAQopLWpMbxMmr32). Use a more specific criteria or set most_recent to true`,
+		},
+		"invalid regex": {
+			config: provider.ImageDataSourceModel{NameRegex: types.StringValue("Ubuntu (")},
+			want:   "name_regex \"Ubuntu (\" is not a valid regular expression: error parsing regexp: missing closing ): `Ubuntu (`",
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			d := &provider.ImageDataSource{}
+			testConfigureDataSource(t, d, testImagesHandler(t))
+
+			resp := testReadDataSource(t, d, &test.config)
+			assert.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, test.want, resp.Diagnostics.Errors()[0].Detail())
+		})
+	}
+}
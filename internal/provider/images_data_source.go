@@ -0,0 +1,441 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ImagesDataSource{}
+
+func NewImagesDataSource() datasource.DataSource {
+	return &ImagesDataSource{}
+}
+
+// ImagesDataSource defines the data source implementation.
+type ImagesDataSource struct {
+	client *clouding.API
+}
+
+// ImagesDataSourceModel describes the data source data model.
+type ImagesDataSourceModel struct {
+	Filters                []ImagesFilterModel `tfsdk:"filter"`
+	MaxPricePerHour        types.Float64       `tfsdk:"max_price_per_hour"`
+	MaxPricePerMonthApprox types.Float64       `tfsdk:"max_price_per_month_approx"`
+	SortBy                 types.String        `tfsdk:"sort_by"`
+	SortOrder              types.String        `tfsdk:"sort_order"`
+	Images                 []ImageItemModel    `tfsdk:"images"`
+}
+
+type ImagesFilterModel struct {
+	Name   types.String   `tfsdk:"name"`
+	Values []types.String `tfsdk:"values"`
+}
+
+// ImageItemModel describes an image of the list. It has the computed attributes of
+// ImageDataSourceModel, without the attributes used to look up a single image.
+type ImageItemModel struct {
+	Id                  types.String             `tfsdk:"id"`
+	Name                types.String             `tfsdk:"name"`
+	MinimumSizeGb       types.Int64              `tfsdk:"minimum_size_gb"`
+	AccessMethods       *ImageAccessMethodsModel `tfsdk:"access_methods"`
+	PricePerHour        types.Float64            `tfsdk:"price_per_hour"`
+	PricePerMonthApprox types.Float64            `tfsdk:"price_per_month_approx"`
+	BillingUnit         types.String             `tfsdk:"billing_unit"`
+}
+
+const (
+	IMAGE_FILTER_NAME           = "name"
+	IMAGE_FILTER_NAME_REGEX     = "name_regex"
+	IMAGE_FILTER_SSH_KEY        = "access_methods.ssh_key"
+	IMAGE_FILTER_PASSWORD       = "access_methods.password"
+	IMAGE_FILTER_MINIMUM_SIZE   = "minimum_size_gb"
+	IMAGE_FILTER_BILLING_UNIT   = "billing_unit"
+	IMAGE_SORT_NAME             = "name"
+	IMAGE_SORT_MINIMUM_SIZE     = "minimum_size_gb"
+	IMAGE_SORT_PRICE_PER_HOUR   = "price_per_hour"
+	IMAGE_SORT_PRICE_PER_MONTH  = "price_per_month_approx"
+	IMAGE_SORT_ORDER_ASCENDING  = "asc"
+	IMAGE_SORT_ORDER_DESCENDING = "desc"
+)
+
+func (d *ImagesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_images"
+}
+
+func (d *ImagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Images data source lists all the images available to create a server, optionally filtered and sorted.",
+
+		Attributes: map[string]schema.Attribute{
+			"max_price_per_hour": schema.Float64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only return images whose price per hour is lower or equal than this value.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_price_per_month_approx": schema.Float64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only return images whose approximate price per month is lower or equal than this value.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"sort_by": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: `Enum: ***name*** ***minimum_size_gb*** ***price_per_hour*** ***price_per_month_approx***` +
+					`The attribute used to sort the images. Names are compared taking their version numbers into account, so "Ubuntu 22.04" comes after "Ubuntu 20.04". By default the images are returned in the API order.`,
+				Validators: []validator.String{
+					stringvalidator.OneOf(IMAGE_SORT_NAME, IMAGE_SORT_MINIMUM_SIZE, IMAGE_SORT_PRICE_PER_HOUR, IMAGE_SORT_PRICE_PER_MONTH),
+				},
+			},
+			"sort_order": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: `Default: ***asc*** Enum: ***asc*** ***desc***` + `The order used to sort the images. It requires sort_by.`,
+				Validators: []validator.String{
+					stringvalidator.OneOf(IMAGE_SORT_ORDER_ASCENDING, IMAGE_SORT_ORDER_DESCENDING),
+					stringvalidator.AlsoRequires(path.MatchRoot("sort_by")),
+				},
+			},
+			"images": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The list of images matching all the filters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: imageAttributes(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.ListNestedBlock{
+				MarkdownDescription: "Only return images matching the filter. When several filters are given an image must match all of them.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required: true,
+							MarkdownDescription: `Enum: ***name*** ***name_regex*** ***access_methods.ssh_key*** ***access_methods.password*** ***minimum_size_gb*** ***billing_unit***` +
+								`The image attribute to filter by. ***name_regex*** matches the image name against [RE2 regular expressions](https://github.com/google/re2/wiki/Syntax). ` +
+								`***minimum_size_gb*** matches the images whose minimum size in gigabytes is greater than or equal to the value.`,
+							Validators: []validator.String{
+								stringvalidator.OneOf(IMAGE_FILTER_NAME, IMAGE_FILTER_NAME_REGEX, IMAGE_FILTER_SSH_KEY, IMAGE_FILTER_PASSWORD, IMAGE_FILTER_MINIMUM_SIZE, IMAGE_FILTER_BILLING_UNIT),
+							},
+						},
+						"values": schema.ListAttribute{
+							Required:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The accepted values of the attribute. An image matches the filter when it matches any of the values.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// imageAttributes returns the computed attributes describing an image.
+func imageAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "A unique string identifier used to reference a Image.",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The name of the image.",
+			Computed:            true,
+		},
+		"minimum_size_gb": schema.Int64Attribute{
+			MarkdownDescription: "The minimum size in gigabytes of the image.",
+			Computed:            true,
+		},
+		"access_methods": schema.SingleNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: "The available access methods for the `accessConfiguration` when [creating a new server](https://api.clouding.io/docs#tag/Servers/operation/CreateServer) from this image.",
+			Attributes: map[string]schema.Attribute{
+				"ssh_key": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: `Enum: ***not-supported*** ***optional*** ***required*** ***required-with-private-key***` + `The SSH key access method of the image.`,
+				},
+				"password": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: `Enum: ***not-supported*** ***optional*** ***required***` + `The password access method of the image.`,
+				},
+			},
+		},
+		"price_per_hour": schema.Float64Attribute{
+			MarkdownDescription: "The price per hour of the image.",
+			Computed:            true,
+		},
+		"price_per_month_approx": schema.Float64Attribute{
+			MarkdownDescription: "The approximate price per month of the image.",
+			Computed:            true,
+		},
+		"billing_unit": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: `The unit used to bill the image, e.g. "Core" means price per server virtual core.`,
+		},
+	}
+}
+
+func (d *ImagesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clouding.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clouding.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ImagesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filters, err := newImageFilters(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Image Filter", err.Error())
+		return
+	}
+
+	images, err := d.client.ListImages(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Images",
+			err.Error(),
+		)
+		return
+	}
+
+	images = filterImages(images, filters)
+	if !state.SortBy.IsNull() {
+		sortImages(images, state.SortBy.ValueString(), state.SortOrder.ValueString() == IMAGE_SORT_ORDER_DESCENDING)
+	}
+
+	state.Images = make([]ImageItemModel, 0, len(images))
+	for _, image := range images {
+		state.Images = append(state.Images, newImageItemModel(image))
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("read images data source, %d images found", len(state.Images)))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// newImageItemModel returns the list item describing the image.
+func newImageItemModel(image clouding.Image) ImageItemModel {
+	return ImageItemModel{
+		Id:                  types.StringValue(image.ID),
+		Name:                types.StringValue(image.Name),
+		MinimumSizeGb:       types.Int64Value(image.MinimumSizeGB),
+		AccessMethods:       newImageAccessMethodsModel(image.AccessMethods),
+		PricePerHour:        types.Float64Value(image.PricePerHour),
+		PricePerMonthApprox: types.Float64Value(image.PricePerMonthApprox),
+		BillingUnit:         types.StringValue(image.BillingUnit),
+	}
+}
+
+// imageFilter reports whether an image matches a filter.
+type imageFilter func(image clouding.Image) bool
+
+// newImageFilters builds the filters of the configuration, every image must match all of them.
+func newImageFilters(state ImagesDataSourceModel) ([]imageFilter, error) {
+	var filters []imageFilter
+
+	for _, filter := range state.Filters {
+		values := make([]string, 0, len(filter.Values))
+		for _, value := range filter.Values {
+			values = append(values, value.ValueString())
+		}
+
+		switch name := filter.Name.ValueString(); name {
+		case IMAGE_FILTER_NAME:
+			filters = append(filters, matchAny(values, func(image clouding.Image) string { return image.Name }))
+		case IMAGE_FILTER_SSH_KEY:
+			filters = append(filters, matchAny(values, func(image clouding.Image) string { return image.AccessMethods.SshKey }))
+		case IMAGE_FILTER_PASSWORD:
+			filters = append(filters, matchAny(values, func(image clouding.Image) string { return image.AccessMethods.Password }))
+		case IMAGE_FILTER_BILLING_UNIT:
+			filters = append(filters, matchAny(values, func(image clouding.Image) string { return image.BillingUnit }))
+		case IMAGE_FILTER_MINIMUM_SIZE:
+			sizes := make([]int64, 0, len(values))
+			for _, value := range values {
+				size, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("filter %s value %q is not an integer", name, value)
+				}
+				sizes = append(sizes, size)
+			}
+			filters = append(filters, func(image clouding.Image) bool {
+				for _, size := range sizes {
+					if image.MinimumSizeGB >= size {
+						return true
+					}
+				}
+				return false
+			})
+		case IMAGE_FILTER_NAME_REGEX:
+			regexps := make([]*regexp.Regexp, 0, len(values))
+			for _, value := range values {
+				re, err := regexp.Compile(value)
+				if err != nil {
+					return nil, fmt.Errorf("filter %s value %q is not a valid regular expression: %s", name, value, err)
+				}
+				regexps = append(regexps, re)
+			}
+			filters = append(filters, func(image clouding.Image) bool {
+				for _, re := range regexps {
+					if re.MatchString(image.Name) {
+						return true
+					}
+				}
+				return false
+			})
+		default:
+			return nil, fmt.Errorf("unknown filter name %q", name)
+		}
+	}
+
+	if !state.MaxPricePerHour.IsNull() {
+		maxPrice := state.MaxPricePerHour.ValueFloat64()
+		filters = append(filters, func(image clouding.Image) bool { return image.PricePerHour <= maxPrice })
+	}
+	if !state.MaxPricePerMonthApprox.IsNull() {
+		maxPrice := state.MaxPricePerMonthApprox.ValueFloat64()
+		filters = append(filters, func(image clouding.Image) bool { return image.PricePerMonthApprox <= maxPrice })
+	}
+
+	return filters, nil
+}
+
+// matchAny returns a filter matching the images whose attribute is any of the values.
+func matchAny(values []string, attribute func(image clouding.Image) string) imageFilter {
+	return func(image clouding.Image) bool {
+		for _, value := range values {
+			if attribute(image) == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func filterImages(images []clouding.Image, filters []imageFilter) []clouding.Image {
+	filtered := make([]clouding.Image, 0, len(images))
+	for _, image := range images {
+		matches := true
+		for _, filter := range filters {
+			if !filter(image) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, image)
+		}
+	}
+	return filtered
+}
+
+// sortImages sorts the images in place by the given attribute, keeping the API order between equal images.
+func sortImages(images []clouding.Image, sortBy string, descending bool) {
+	sort.SliceStable(images, func(i, j int) bool {
+		a, b := images[i], images[j]
+		if descending {
+			a, b = b, a
+		}
+		switch sortBy {
+		case IMAGE_SORT_MINIMUM_SIZE:
+			return a.MinimumSizeGB < b.MinimumSizeGB
+		case IMAGE_SORT_PRICE_PER_HOUR:
+			return a.PricePerHour < b.PricePerHour
+		case IMAGE_SORT_PRICE_PER_MONTH:
+			return a.PricePerMonthApprox < b.PricePerMonthApprox
+		default:
+			return compareVersions(a.Name, b.Name) < 0
+		}
+	})
+}
+
+// compareVersions compares two image names, comparing the runs of digits as numbers so
+// "Ubuntu 22.04" is greater than "Ubuntu 9.10". It returns -1, 0 or +1.
+func compareVersions(a, b string) int {
+	for a != "" && b != "" {
+		aDigits, bDigits := isDigit(a[0]), isDigit(b[0])
+		switch {
+		case aDigits && bDigits:
+			aNumber, aRest := splitDigits(a)
+			bNumber, bRest := splitDigits(b)
+			aNumber, bNumber = strings.TrimLeft(aNumber, "0"), strings.TrimLeft(bNumber, "0")
+			if len(aNumber) != len(bNumber) {
+				return compareInts(len(aNumber), len(bNumber))
+			}
+			if c := strings.Compare(aNumber, bNumber); c != 0 {
+				return c
+			}
+			a, b = aRest, bRest
+		case a[0] != b[0]:
+			return compareInts(int(a[0]), int(b[0]))
+		default:
+			a, b = a[1:], b[1:]
+		}
+	}
+	return compareInts(len(a), len(b))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func splitDigits(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
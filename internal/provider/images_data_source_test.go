@@ -0,0 +1,188 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+// testImagesHandler answers the list of images as the Clouding API does.
+func testImagesHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/images", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`
+		{
+		  "images": [
+		    {
+		      "id": "wLQbN5nvg829JaeZ",
+		      "name": "Debian 11 (64 Bit)",
+		      "minimumSizeGb": 5,
+		      "accessMethods": {"sshKey": "optional", "password": "optional"},
+		      "pricePerHour": 0,
+		      "pricePerMonthApprox": 0,
+		      "billingUnit": "Core"
+		    },
+		    {
+		      "id": "lo1qJ9oZb1xGMEgD",
+		      "name": "Ubuntu 22.04 (64 Bit)",
+		      "minimumSizeGb": 5,
+		      "accessMethods": {"sshKey": "optional", "password": "optional"},
+		      "pricePerHour": 0,
+		      "pricePerMonthApprox": 0,
+		      "billingUnit": "Core"
+		    },
+		    {
+		      "id": "NAQopLWpMbxMmr32",
+		      "name": "Ubuntu 9.10 (64 Bit)",
+		      "minimumSizeGb": 5,
+		      "accessMethods": {"sshKey": "required", "password": "not-supported"},
+		      "pricePerHour": 0,
+		      "pricePerMonthApprox": 0,
+		      "billingUnit": "Core"
+		    },
+		    {
+		      "id": "d3mKbx4zd3XEQaqP",
+		      "name": "Windows Server 2022 (English 64Bit)",
+		      "minimumSizeGb": 25,
+		      "accessMethods": {"sshKey": "not-supported", "password": "required"},
+		      "pricePerHour": 0.00684,
+		      "pricePerMonthApprox": 4.9932,
+		      "billingUnit": "Core"
+		    }
+		  ],
+		  "links": {"next": null},
+		  "meta": {"total": 4}
+		}
+		`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}
+}
+
+func testImageIDs(resp provider.ImagesDataSourceModel) []string {
+	ids := make([]string, 0, len(resp.Images))
+	for _, image := range resp.Images {
+		ids = append(ids, image.Id.ValueString())
+	}
+	return ids
+}
+
+func testReadImages(t *testing.T, config provider.ImagesDataSourceModel) provider.ImagesDataSourceModel {
+	d := &provider.ImagesDataSource{}
+	testConfigureDataSource(t, d, testImagesHandler(t))
+
+	resp := testReadDataSource(t, d, &config)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state provider.ImagesDataSourceModel
+	diags := resp.State.Get(context.Background(), &state)
+	assert.False(t, diags.HasError(), diags)
+	return state
+}
+
+func TestImagesDataSource(t *testing.T) {
+	t.Parallel()
+	state := testReadImages(t, provider.ImagesDataSourceModel{})
+
+	assert.Equal(t, []string{"wLQbN5nvg829JaeZ", "lo1qJ9oZb1xGMEgD", "NAQopLWpMbxMmr32", "d3mKbx4zd3XEQaqP"}, testImageIDs(state))
+	assert.Equal(t, "Windows Server 2022 (English 64Bit)", state.Images[3].Name.ValueString())
+	assert.Equal(t, int64(25), state.Images[3].MinimumSizeGb.ValueInt64())
+	assert.Equal(t, "required", state.Images[3].AccessMethods.Password.ValueString())
+	assert.Equal(t, 4.9932, state.Images[3].PricePerMonthApprox.ValueFloat64())
+}
+
+func TestImagesDataSourceFilters(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		config provider.ImagesDataSourceModel
+		want   []string
+	}{
+		"name": {
+			config: provider.ImagesDataSourceModel{Filters: []provider.ImagesFilterModel{
+				{Name: types.StringValue("name"), Values: []types.String{types.StringValue("Debian 11 (64 Bit)"), types.StringValue("Ubuntu 9.10 (64 Bit)")}},
+			}},
+			want: []string{"wLQbN5nvg829JaeZ", "NAQopLWpMbxMmr32"},
+		},
+		"name regex and ssh key": {
+			config: provider.ImagesDataSourceModel{Filters: []provider.ImagesFilterModel{
+				{Name: types.StringValue("name_regex"), Values: []types.String{types.StringValue("^Ubuntu")}},
+				{Name: types.StringValue("access_methods.ssh_key"), Values: []types.String{types.StringValue("optional")}},
+			}},
+			want: []string{"lo1qJ9oZb1xGMEgD"},
+		},
+		"password and minimum size": {
+			config: provider.ImagesDataSourceModel{Filters: []provider.ImagesFilterModel{
+				{Name: types.StringValue("access_methods.password"), Values: []types.String{types.StringValue("required"), types.StringValue("optional")}},
+				{Name: types.StringValue("minimum_size_gb"), Values: []types.String{types.StringValue("25")}},
+			}},
+			want: []string{"d3mKbx4zd3XEQaqP"},
+		},
+		"minimum size at least": {
+			config: provider.ImagesDataSourceModel{Filters: []provider.ImagesFilterModel{
+				{Name: types.StringValue("minimum_size_gb"), Values: []types.String{types.StringValue("10")}},
+			}},
+			want: []string{"d3mKbx4zd3XEQaqP"},
+		},
+		"billing unit and price": {
+			config: provider.ImagesDataSourceModel{
+				Filters: []provider.ImagesFilterModel{
+					{Name: types.StringValue("billing_unit"), Values: []types.String{types.StringValue("Core")}},
+				},
+				MaxPricePerMonthApprox: types.Float64Value(1),
+			},
+			want: []string{"wLQbN5nvg829JaeZ", "lo1qJ9oZb1xGMEgD", "NAQopLWpMbxMmr32"},
+		},
+		"no match": {
+			config: provider.ImagesDataSourceModel{Filters: []provider.ImagesFilterModel{
+				{Name: types.StringValue("name_regex"), Values: []types.String{types.StringValue("CentOS")}},
+			}},
+			want: []string{},
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			state := testReadImages(t, test.config)
+			assert.Equal(t, test.want, testImageIDs(state))
+		})
+	}
+}
+
+func TestImagesDataSourceSort(t *testing.T) {
+	t.Parallel()
+	state := testReadImages(t, provider.ImagesDataSourceModel{
+		Filters: []provider.ImagesFilterModel{
+			{Name: types.StringValue("name_regex"), Values: []types.String{types.StringValue("^Ubuntu")}},
+		},
+		SortBy:    types.StringValue("name"),
+		SortOrder: types.StringValue("desc"),
+	})
+	assert.Equal(t, []string{"lo1qJ9oZb1xGMEgD", "NAQopLWpMbxMmr32"}, testImageIDs(state))
+
+	state = testReadImages(t, provider.ImagesDataSourceModel{
+		SortBy: types.StringValue("price_per_hour"),
+	})
+	assert.Equal(t, "d3mKbx4zd3XEQaqP", state.Images[3].Id.ValueString())
+}
+
+func TestImagesDataSourceInvalidRegex(t *testing.T) {
+	t.Parallel()
+	d := &provider.ImagesDataSource{}
+	testConfigureDataSource(t, d, testImagesHandler(t))
+
+	resp := testReadDataSource(t, d, &provider.ImagesDataSourceModel{
+		Filters: []provider.ImagesFilterModel{
+			{Name: types.StringValue("name_regex"), Values: []types.String{types.StringValue("Ubuntu (")}},
+		},
+	})
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Invalid Image Filter", resp.Diagnostics.Errors()[0].Summary())
+}
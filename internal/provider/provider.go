@@ -5,13 +5,18 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
@@ -38,7 +43,11 @@ func New(version string) func() provider.Provider {
 
 // CloudingProviderModel describes the provider data model.
 type CloudingProviderModel struct {
-	Token types.String `tfsdk:"token"`
+	Token             types.String  `tfsdk:"token"`
+	MaxRetries        types.Int64   `tfsdk:"max_retries"`
+	RetryWaitMin      types.Int64   `tfsdk:"retry_wait_min"`
+	RetryWaitMax      types.Int64   `tfsdk:"retry_wait_max"`
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
 }
 
 func (p *CloudingProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -57,6 +66,38 @@ func (p *CloudingProvider) Schema(ctx context.Context, req provider.SchemaReques
 				MarkdownDescription: "The token of the clouding API.",
 				Optional:            true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Default: 3" +
+					"The maximum number of times a request is retried when the Clouding API is rate limiting or temporarily unavailable. Set to 0 to disable retries.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.Int64Attribute{
+				MarkdownDescription: "Default: 1" +
+					"The minimum time in seconds to wait between retries.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_wait_max": schema.Int64Attribute{
+				MarkdownDescription: "Default: 30" +
+					"The maximum time in seconds to wait between retries, it also bounds the wait asked by the Retry-After header of the Clouding API.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Default: 0" +
+					"The maximum number of requests per second sent to the Clouding API, shared by all resources and data sources. Set to 0 to disable the rate limit.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
 		},
 	}
 }
@@ -114,8 +155,33 @@ func (p *CloudingProvider) Configure(ctx context.Context, req provider.Configure
 
 	tflog.Debug(ctx, "Creating the Clouding provider")
 
+	// Set the retry policy, values not present in the provider configuration keep the client defaults
+	maxRetries := clouding.RETRY_MAX
+	retryWaitMin := clouding.RETRY_WAIT_MIN
+	retryWaitMax := clouding.RETRY_WAIT_MAX
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.RetryWaitMin.IsNull() {
+		retryWaitMin = time.Duration(config.RetryWaitMin.ValueInt64()) * time.Second
+	}
+	if !config.RetryWaitMax.IsNull() {
+		retryWaitMax = time.Duration(config.RetryWaitMax.ValueInt64()) * time.Second
+	}
+	if retryWaitMax < retryWaitMin {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_max"),
+			"Invalid Clouding API retry wait",
+			fmt.Sprintf("The retry_wait_max value (%s) must be greater than or equal to retry_wait_min (%s).", retryWaitMax, retryWaitMin),
+		)
+		return
+	}
+
 	// Example client configuration for data sources and resources
-	client, err := clouding.NewAPI(token)
+	client, err := clouding.NewAPI(token,
+		clouding.WithRetry(maxRetries, retryWaitMin, retryWaitMax),
+		clouding.WithRateLimit(config.RequestsPerSecond.ValueFloat64()),
+	)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating the Clouding API client",
@@ -133,17 +199,25 @@ func (p *CloudingProvider) Configure(ctx context.Context, req provider.Configure
 func (p *CloudingProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewFirewallResource,
+		NewFirewallAttachmentResource,
 		NewFirewallRuleResource,
 		NewServerResource,
+		NewServerRebootResource,
+		NewSnapShotResource,
 		NewSshKeyResource,
 	}
 }
 
 func (p *CloudingProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewBackupDataSource,
 		NewFirewallDataSource,
+		NewFlavorsDataSource,
 		NewImageDataSource,
+		NewImagesDataSource,
+		NewServerDataSource,
 		NewSnapshotDataSource,
 		NewSshkeyDataSource,
+		NewVolumeSizesDataSource,
 	}
 }
@@ -4,8 +4,18 @@
 package provider_test
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
 	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
 )
 
@@ -16,3 +26,96 @@ import (
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"clouding": providerserver.NewProtocol6WithError(provider.New("test")()),
 }
+
+// testNotFoundHandler answers every request as the Clouding API does for a missing resource.
+func testNotFoundHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(`{"title": "Not Found", "status": 404, "traceId": "00000000-0000-0000-0000-000000000000"}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}
+}
+
+// testClient returns a client pointing to a mock Clouding API.
+func testClient(t *testing.T, handler http.Handler) *clouding.API {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := clouding.NewAPI("token123", clouding.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("getting error creating NewAPI: %s", err)
+	}
+	return client
+}
+
+// testConfigureResource configures the resource with a client pointing to a mock Clouding API.
+func testConfigureResource(t *testing.T, r fwresource.ResourceWithConfigure, handler http.Handler) {
+	var resp fwresource.ConfigureResponse
+	r.Configure(context.Background(), fwresource.ConfigureRequest{ProviderData: testClient(t, handler)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("getting error configuring resource: %v", resp.Diagnostics)
+	}
+}
+
+// testResourceState builds a resource state holding the given model.
+func testResourceState(t *testing.T, r fwresource.Resource, model any) tfsdk.State {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.Set(ctx, model)
+	if diags.HasError() {
+		t.Fatalf("getting error setting state: %v", diags)
+	}
+	return state
+}
+
+// testConfigureDataSource configures the data source with a client pointing to a mock Clouding API.
+func testConfigureDataSource(t *testing.T, d datasource.DataSourceWithConfigure, handler http.Handler) {
+	var resp datasource.ConfigureResponse
+	d.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: testClient(t, handler)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("getting error configuring data source: %v", resp.Diagnostics)
+	}
+}
+
+// testReadDataSource reads the data source with the given configuration model and
+// returns the response holding the resulting state.
+func testReadDataSource(t *testing.T, d datasource.DataSource, model any) datasource.ReadResponse {
+	ctx := context.Background()
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.Set(ctx, model)
+	if diags.HasError() {
+		t.Fatalf("getting error setting config: %v", diags)
+	}
+
+	resp := datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, &resp)
+	return resp
+}
+
+// testResourcePlan builds a resource plan holding the given model.
+func testResourcePlan(t *testing.T, r fwresource.Resource, model any) tfsdk.Plan {
+	state := testResourceState(t, r, model)
+	return tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+}
@@ -3,12 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -20,32 +20,32 @@ func NewServerDataSource() datasource.DataSource {
 
 // ServerDataSource defines the data source implementation.
 type ServerDataSource struct {
-	client *http.Client
+	client *clouding.API
 }
 
 // ServerDataSourceModel describes the data source data model.
 type ServerDataSourceModel struct {
-	Id                    types.String          `tfsdk:"id"`
-	Name                  types.String          `tfsdk:"name"`
-	Hostname              types.String          `tfsdk:"hostname"`
-	Vcores                types.Number          `tfsdk:"vcores"`
-	RamGB                 types.Number          `tfsdk:"ram_gb"`
-	Flavor                types.String          `tfsdk:"flavor"`
-	VolumeSizeGB          types.Number          `tfsdk:"volume_size_gb"`
-	ImageModel            ImageModel            `tfsdk:"image"`
-	Status                types.String          `tfsdk:"status"`
-	PowerState            types.String          `tfsdk:"power_state"`
-	Features              []types.String        `tfsdk:"features"`
-	CreatedAt             types.String          `tfsdk:"created_at"`
-	DnsAddresses          types.String          `tfsdk:"dns_addresses"`
-	PublicIP              types.String          `tfsdk:"public_ip"`
-	PrivateIP             types.String          `tfsdk:"private_ip"`
-	SshKeyID              types.String          `tfsdk:"ssh_key_id"`
-	Firewalls             []FirewallModel       `tfsdk:"firewalls"`
-	Snapshots             []SnapshotsModel      `tfsdk:"snapshots"`
-	BackupsModel          []BackupsModel        `tfsdk:"backups"`
-	BackupPreferenceModel BackupPreferenceModel `tfsdk:"backup_preference"`
-	CostModel             CostModel             `tfsdk:"cost"`
+	Id                    types.String           `tfsdk:"id"`
+	Name                  types.String           `tfsdk:"name"`
+	Hostname              types.String           `tfsdk:"hostname"`
+	Vcores                types.Float64          `tfsdk:"vcores"`
+	RamGB                 types.Int64            `tfsdk:"ram_gb"`
+	Flavor                types.String           `tfsdk:"flavor"`
+	VolumeSizeGB          types.Int64            `tfsdk:"volume_size_gb"`
+	ImageModel            ImageModel             `tfsdk:"image"`
+	Status                types.String           `tfsdk:"status"`
+	PowerState            types.String           `tfsdk:"power_state"`
+	Features              []types.String         `tfsdk:"features"`
+	CreatedAt             types.String           `tfsdk:"created_at"`
+	DnsAddress            types.String           `tfsdk:"dns_address"`
+	PublicIP              types.String           `tfsdk:"public_ip"`
+	PrivateIP             types.String           `tfsdk:"private_ip"`
+	SshKeyID              types.String           `tfsdk:"ssh_key_id"`
+	Firewalls             []FirewallModel        `tfsdk:"firewalls"`
+	Snapshots             []SnapshotsModel       `tfsdk:"snapshots"`
+	BackupsModel          []BackupsModel         `tfsdk:"backups"`
+	BackupPreferenceModel *BackupPreferenceModel `tfsdk:"backup_preference"`
+	CostModel             CostModel              `tfsdk:"cost"`
 }
 
 type ImageModel struct {
@@ -71,8 +71,8 @@ type BackupsModel struct {
 }
 
 type CostModel struct {
-	PricePerHour        types.Number `tfsdk:"price_per_hour"`
-	PricePerMonthApprox types.Number `tfsdk:"price_per_month_approx"`
+	PricePerHour        types.Float64 `tfsdk:"price_per_hour"`
+	PricePerMonthApprox types.Float64 `tfsdk:"price_per_month_approx"`
 }
 
 func (d *ServerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -96,12 +96,13 @@ func (d *ServerDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 			},
 			"hostname": schema.StringAttribute{
 				MarkdownDescription: "The Server hostname.",
+				Computed:            true,
 			},
-			"vcores": schema.NumberAttribute{
+			"vcores": schema.Float64Attribute{
 				MarkdownDescription: "The number of virtual cores allocated for the server.",
 				Computed:            true,
 			},
-			"ram_gb": schema.NumberAttribute{
+			"ram_gb": schema.Int64Attribute{
 				MarkdownDescription: "The amount of RAM in GB allocated for the server.",
 				Computed:            true,
 			},
@@ -109,7 +110,7 @@ func (d *ServerDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				MarkdownDescription: "The flavor of the server.",
 				Computed:            true,
 			},
-			"volume_size_gb": schema.NumberAttribute{
+			"volume_size_gb": schema.Int64Attribute{
 				MarkdownDescription: "The size of the server's disk in GB.",
 				Computed:            true,
 			},
@@ -138,7 +139,8 @@ func (d *ServerDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 					"The power state of the server.",
 			},
 			"features": schema.ListAttribute{
-				Computed: true,
+				Computed:    true,
+				ElementType: types.StringType,
 				MarkdownDescription: "The features that are applied to the server. The possible features are:" +
 					"- **AllowSmtpOut:** The Allow SMTP Out feature allows the server to send emails. This feature is disabled by default. To enable it use the Allow server SMTP out endpoint." +
 					"- **AntiDDoSNetworkFilter:** The [strict Anti-DDoS filtering](https://help.clouding.io/hc/en-us/articles/6310749915036) can protect the server under constant DDoS attacks by filtering out incoming malicious traffic. This feature can only be enabled during server creation by setting the value of enableStrictAntiDDoSFiltering to true. This feature cannot be disabled." +
@@ -149,9 +151,9 @@ func (d *ServerDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Computed:            true,
 				MarkdownDescription: "The date and time when the server was created.",
 			},
-			"dns_addresses": schema.StringAttribute{
+			"dns_address": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "The DNS addresses of the server. The DNS address points to the public IP of the server.",
+				MarkdownDescription: "The DNS address of the server. The DNS address points to the public IP of the server.",
 			},
 			"public_ip": schema.StringAttribute{
 				Computed:            true,
@@ -226,7 +228,7 @@ func (d *ServerDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Computed:            true,
 				MarkdownDescription: "The backup strategy of the server.",
 				Attributes: map[string]schema.Attribute{
-					"slots": schema.NumberAttribute{
+					"slots": schema.Int64Attribute{
 						Computed:            true,
 						MarkdownDescription: "The number of backups maintained.",
 					},
@@ -240,11 +242,11 @@ func (d *ServerDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Computed:            true,
 				MarkdownDescription: "The cost of the server.",
 				Attributes: map[string]schema.Attribute{
-					"price_per_hour": schema.NumberAttribute{
+					"price_per_hour": schema.Float64Attribute{
 						Computed:            true,
 						MarkdownDescription: "The hourly price of the server.",
 					},
-					"price_per_month_approx": schema.NumberAttribute{
+					"price_per_month_approx": schema.Float64Attribute{
 						Computed:            true,
 						MarkdownDescription: "The approximate monthly price of the server.",
 					},
@@ -260,12 +262,12 @@ func (d *ServerDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*clouding.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *clouding.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -284,16 +286,74 @@ func (d *ServerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := d.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
-
-	// For the purposes of this example code, hardcoding a response value to
+	server := clouding.Server{
+		ID: state.Id.ValueString(),
+	}
+	err := d.client.GetServerID(ctx, &server)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Server",
+			err.Error(),
+		)
+		return
+	}
 	// save into the Terraform state.
+	state.Id = types.StringValue(server.ID)
+	state.Name = types.StringValue(server.Name)
+	state.Hostname = types.StringValue(server.Hostname)
+	state.Vcores = types.Float64Value(server.VCores)
+	state.RamGB = types.Int64Value(int64(server.RamGb))
+	state.Flavor = types.StringValue(server.Flavor)
+	state.VolumeSizeGB = types.Int64Value(server.VolumeSizeGb)
+	state.ImageModel = ImageModel{
+		Id:   types.StringValue(server.Image.ID),
+		Name: types.StringValue(server.Image.Name),
+	}
+	state.Status = types.StringValue(server.Status)
+	state.PowerState = types.StringValue(server.PowerState)
+	state.Features = make([]types.String, 0, len(server.Features))
+	for _, feature := range server.Features {
+		state.Features = append(state.Features, types.StringValue(feature))
+	}
+	state.CreatedAt = types.StringValue(server.CreatedAt)
+	state.DnsAddress = types.StringValue(server.DnsAddress)
+	state.PublicIP = types.StringValue(server.PublicIP)
+	state.PrivateIP = types.StringValue(server.PrivateIP)
+	state.SshKeyID = types.StringValue(server.SshKeyID)
+	state.Firewalls = make([]FirewallModel, 0, len(server.Firewalls))
+	for _, firewall := range server.Firewalls {
+		state.Firewalls = append(state.Firewalls, FirewallModel{
+			Id:   types.StringValue(firewall.ID),
+			Name: types.StringValue(firewall.Name),
+		})
+	}
+	state.Snapshots = make([]SnapshotsModel, 0, len(server.Snapshots))
+	for _, snapshot := range server.Snapshots {
+		state.Snapshots = append(state.Snapshots, SnapshotsModel{
+			ID:        types.StringValue(snapshot.ID),
+			Name:      types.StringValue(snapshot.Name),
+			CreatedAt: types.StringValue(snapshot.CreatedAt),
+		})
+	}
+	state.BackupsModel = make([]BackupsModel, 0, len(server.Backups))
+	for _, backup := range server.Backups {
+		state.BackupsModel = append(state.BackupsModel, BackupsModel{
+			ID:        types.StringValue(backup.ID),
+			CreatedAt: types.StringValue(backup.CreatedAt),
+			Status:    types.StringValue(backup.Status),
+		})
+	}
+	state.BackupPreferenceModel = nil
+	if server.BackupPreference != nil {
+		state.BackupPreferenceModel = &BackupPreferenceModel{
+			Slots:     types.Int64Value(server.BackupPreference.Slots),
+			Frequency: types.StringValue(server.BackupPreference.Frequency),
+		}
+	}
+	state.CostModel = CostModel{
+		PricePerHour:        types.Float64Value(server.Cost.PricePerHour),
+		PricePerMonthApprox: types.Float64Value(server.Cost.PricePerMonthApprox),
+	}
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
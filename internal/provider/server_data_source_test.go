@@ -0,0 +1,114 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerDataSource(t *testing.T) {
+	t.Parallel()
+	d := &provider.ServerDataSource{}
+	testConfigureDataSource(t, d, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/servers/ke8vlrXPjxO1oq3m", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`
+			{
+			  "id": "ke8vlrXPjxO1oq3m",
+			  "name": "database-server",
+			  "hostname": "db.example.com",
+			  "vCores": 0.5,
+			  "ramGb": 4,
+			  "flavor": "0.5x4",
+			  "volumeSizeGb": 15,
+			  "image": {
+			    "id": "lo1qJ9oZb1xGMEgD",
+			    "name": "CelestiaOS 2.04 (64 Bit)"
+			  },
+			  "status": "Active",
+			  "powerState": "Running",
+			  "features": [
+			    "Backups",
+			    "PrivateNetwork"
+			  ],
+			  "createdAt": "2022-12-19T12:00:00.0000000Z",
+			  "dnsAddress": "0447ff27-2d5f-4888-9822-46ea09048cb4.clouding.host",
+			  "publicIp": "185.256.254.180",
+			  "privateIp": "10.20.10.1",
+			  "sshKeyId": "Dd8v0nXJ1924rayY",
+			  "firewalls": [
+			    {
+			      "id": "LywOkvx5LWAp28NP",
+			      "name": "Allow all private traffic"
+			    },
+			    {
+			      "id": "JLB82xyP8aWOrqeN",
+			      "name": "Allow MySQL"
+			    }
+			  ],
+			  "snapshots": [],
+			  "backups": [
+			    {
+			      "id": "3lo1qJ9oO19GMEgD",
+			      "createdAt": "2023-01-03T12:00:00.0000000Z",
+			      "status": "Creating"
+			    }
+			  ],
+			  "backupPreferences": {
+			    "slots": 4,
+			    "frequency": "OneDay"
+			  },
+			  "cost": {
+			    "pricePerHour": 0.014004,
+			    "pricePerMonthApprox": 10.22292
+			  }
+			}
+		`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	}))
+
+	resp := testReadDataSource(t, d, &provider.ServerDataSourceModel{
+		Id: types.StringValue("ke8vlrXPjxO1oq3m"),
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state provider.ServerDataSourceModel
+	diags := resp.State.Get(context.Background(), &state)
+	assert.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, "database-server", state.Name.ValueString())
+	assert.Equal(t, "db.example.com", state.Hostname.ValueString())
+	assert.Equal(t, 0.5, state.Vcores.ValueFloat64())
+	assert.Equal(t, int64(4), state.RamGB.ValueInt64())
+	assert.Equal(t, int64(15), state.VolumeSizeGB.ValueInt64())
+	assert.Equal(t, "lo1qJ9oZb1xGMEgD", state.ImageModel.Id.ValueString())
+	assert.Equal(t, []types.String{types.StringValue("Backups"), types.StringValue("PrivateNetwork")}, state.Features)
+	assert.Equal(t, "0447ff27-2d5f-4888-9822-46ea09048cb4.clouding.host", state.DnsAddress.ValueString())
+	assert.Equal(t, "185.256.254.180", state.PublicIP.ValueString())
+	assert.Equal(t, "10.20.10.1", state.PrivateIP.ValueString())
+	assert.Len(t, state.Firewalls, 2)
+	assert.Equal(t, "JLB82xyP8aWOrqeN", state.Firewalls[1].Id.ValueString())
+	assert.Empty(t, state.Snapshots)
+	assert.Len(t, state.BackupsModel, 1)
+	assert.Equal(t, int64(4), state.BackupPreferenceModel.Slots.ValueInt64())
+	assert.Equal(t, 0.014004, state.CostModel.PricePerHour.ValueFloat64())
+}
+
+func TestServerDataSourceNotFound(t *testing.T) {
+	t.Parallel()
+	d := &provider.ServerDataSource{}
+	testConfigureDataSource(t, d, testNotFoundHandler(t))
+
+	resp := testReadDataSource(t, d, &provider.ServerDataSourceModel{
+		Id: types.StringValue("ke8vlrXPjxO1oq3m"),
+	})
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "returned status code: 404")
+}
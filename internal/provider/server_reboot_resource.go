@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ServerRebootResource{}
+
+// Values of the type attribute of a server reboot.
+const (
+	REBOOT_TYPE_SOFT = "soft"
+	REBOOT_TYPE_HARD = "hard"
+)
+
+func NewServerRebootResource() resource.Resource {
+	return &ServerRebootResource{}
+}
+
+// ServerRebootResource defines the resource implementation.
+type ServerRebootResource struct {
+	client *clouding.API
+}
+
+// ServerRebootResourceModel describes the resource data model.
+type ServerRebootResourceModel struct {
+	Id          types.String   `tfsdk:"id"`
+	ServerID    types.String   `tfsdk:"server_id"`
+	Type        types.String   `tfsdk:"type"`
+	Triggers    types.Map      `tfsdk:"triggers"`
+	CompletedAt types.String   `tfsdk:"completed_at"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ServerRebootResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_reboot"
+}
+
+func (r *ServerRebootResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reboots a server when it is created and every time its `triggers` change, for example when a configuration file of the server changes. " +
+			"The reboot is done once, destroying this resource does not affect the server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the action of the last reboot.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the server to reboot.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Enum: ```soft``` ```hard``` Default: soft" +
+					"A soft reboot restarts the server gracefully, a hard reboot cuts its power like pressing the reset button. Changing it reboots the server again.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(REBOOT_TYPE_SOFT),
+				Validators: []validator.String{
+					stringvalidator.OneOf(REBOOT_TYPE_SOFT, REBOOT_TYPE_HARD),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that reboot the server when any of them changes.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"completed_at": schema.StringAttribute{
+				MarkdownDescription: "The datetime when the last reboot completed.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *ServerRebootResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clouding.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clouding.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ServerRebootResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ServerRebootResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	reboot := r.client.RebootServer
+	if plan.Type.ValueString() == REBOOT_TYPE_HARD {
+		reboot = r.client.HardRebootServer
+	}
+
+	action, err := reboot(ctx, plan.ServerID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to reboot server, got error: %s", err))
+		return
+	}
+
+	// Wait for reboot action to complete
+	err = r.client.WaitForAction(ctx, &action, 5*time.Second, clouding.WithMaxPollInterval(30*time.Second))
+	if err != nil {
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to wait for server reboot action, got error: %s", err))
+		return
+	}
+
+	plan.Id = types.StringValue(action.ID)
+	plan.CompletedAt = types.StringValue(action.CompletedAt)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("Server %s rebooted", plan.ServerID.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read keeps the state as it is, a reboot is a one-off operation without anything to refresh.
+func (r *ServerRebootResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ServerRebootResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only saves the timeouts, any other change replaces the resource and reboots the server.
+func (r *ServerRebootResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ServerRebootResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete only removes the resource from the state, the server is left untouched.
+func (r *ServerRebootResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
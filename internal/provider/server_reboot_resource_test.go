@@ -0,0 +1,80 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerRebootResourceCreate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		rebootType string
+		path       string
+	}{
+		"soft": {
+			rebootType: "soft",
+			path:       "/v1/servers/Q7y1OZWlknXmk6l3/reboot",
+		},
+		"hard": {
+			rebootType: "hard",
+			path:       "/v1/servers/Q7y1OZWlknXmk6l3/hard-reboot",
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mux := http.NewServeMux()
+			mux.HandleFunc(test.path, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusAccepted)
+				_, err := w.Write([]byte(`{"id": "K9xnYDvBQ4mOlJRa", "status": "inProgress", "resourceId": "Q7y1OZWlknXmk6l3"}`))
+				if err != nil {
+					t.Errorf("error writing response: %s", err)
+				}
+			})
+			mux.HandleFunc("/v1/actions/K9xnYDvBQ4mOlJRa", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(`{"id": "K9xnYDvBQ4mOlJRa", "status": "completed", "completedAt": "2023-01-03T12:01:00.0000000Z", "resourceId": "Q7y1OZWlknXmk6l3"}`))
+				if err != nil {
+					t.Errorf("error writing response: %s", err)
+				}
+			})
+			mux.Handle("/", testNotFoundHandler(t))
+
+			r := &provider.ServerRebootResource{}
+			testConfigureResource(t, r, mux)
+			plan := testResourcePlan(t, r, &provider.ServerRebootResourceModel{
+				Id:          types.StringUnknown(),
+				ServerID:    types.StringValue("Q7y1OZWlknXmk6l3"),
+				Type:        types.StringValue(test.rebootType),
+				Triggers:    types.MapValueMust(types.StringType, map[string]attr.Value{"config": types.StringValue("a1b2c3")}),
+				CompletedAt: types.StringUnknown(),
+				Timeouts: timeouts.Value{
+					Object: types.ObjectNull(map[string]attr.Type{"create": types.StringType}),
+				},
+			})
+
+			resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var state provider.ServerRebootResourceModel
+			resp.State.Get(ctx, &state)
+			assert.Equal(t, "K9xnYDvBQ4mOlJRa", state.Id.ValueString())
+			assert.Equal(t, "2023-01-03T12:01:00.0000000Z", state.CompletedAt.ValueString())
+		})
+	}
+}
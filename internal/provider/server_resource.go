@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -19,6 +23,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
@@ -27,6 +32,13 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ServerResource{}
 var _ resource.ResourceWithImportState = &ServerResource{}
+var _ resource.ResourceWithModifyPlan = &ServerResource{}
+
+// Values of the power_state attribute of a server.
+const (
+	POWER_STATE_ON  = "on"
+	POWER_STATE_OFF = "off"
+)
 
 func NewServerResource() resource.Resource {
 	return &ServerResource{}
@@ -50,6 +62,8 @@ type ServerResourceModel struct {
 	EnableStrictAntiDDoSFiltering types.Bool                `tfsdk:"enable_strict_antiddos_filtering"`
 	UserData                      types.String              `tfsdk:"user_data"`
 	BackupPreference              *BackupPreferenceModel    `tfsdk:"backup_preference"`
+	PowerState                    types.String              `tfsdk:"power_state"`
+	AllowStopForUpdate            types.Bool                `tfsdk:"allow_stop_for_update"`
 	LastUpdated                   types.String              `tfsdk:"last_updated"`
 	Timeouts                      timeouts.Value            `tfsdk:"timeouts"`
 }
@@ -107,21 +121,15 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"flavor_id": schema.StringAttribute{
-				MarkdownDescription: "The identifier of the desired flavor size. Flavors are pre-defined configurations of CPU and RAM. The list of available flavors can be retrieved from the [flavor sizes](https://api.clouding.io/docs#tag/Sizes/operation/ListAllFlavors) endpoint.",
+				MarkdownDescription: "The identifier of the desired flavor size. Flavors are pre-defined configurations of CPU and RAM. The list of available flavors can be retrieved from the `clouding_flavors` data source, an unknown flavor is rejected at plan time. Changing it resizes the server in place, which requires `allow_stop_for_update` to be true.",
 				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"firewall_id": schema.StringAttribute{
-				MarkdownDescription: "The identifier of the initial firewall that will be attached to the server. Firewalls can be attached or detached after server creation.",
+				MarkdownDescription: "The identifier of the firewall attached to the server. Changing it attaches the new firewall and then detaches the previous one, without replacing the server. Other firewalls can be attached with the `clouding_firewall_attachment` resource.",
 				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"access_configuration": schema.SingleNestedAttribute{
-				MarkdownDescription: "When creating a server, you need to choose a method to access it. The two options are SSH key authentication and password authentication. The availability and requirements of these methods depend on the accessMethods of the volume's source.",
+				MarkdownDescription: "When creating a server, you need to choose a method to access it. The two options are SSH key authentication and password authentication. The availability and requirements of these methods depend on the accessMethods of the volume's source, they are checked at plan time.",
 				Required:            true,
 				Attributes: map[string]schema.Attribute{
 					"ssh_key_id": schema.StringAttribute{
@@ -181,13 +189,20 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					},
 					"ssd_gb": schema.Int64Attribute{
 						MarkdownDescription: "Minimum: >=5" +
-							"The size of the volume in gigabytes. The minimum size depends on the source. For example if the source is snapshot and the snapshot is 20 gigabytes, this property should be set to minimum 20 gigabytes. The list of available volume sizes can be retrieved from the volume sizes endpoint.",
+							"The size of the volume in gigabytes. The minimum size depends on the source. For example if the source is snapshot and the snapshot is 20 gigabytes, this property should be set to minimum 20 gigabytes. The list of available volume sizes can be retrieved from the `clouding_volume_sizes` data source. Both are checked at plan time. Growing the volume is done in place, shrinking it requires replacement.",
 						Required: true,
 						Validators: []validator.Int64{
 							int64validator.AtLeast(5),
 						},
 						PlanModifiers: []planmodifier.Int64{
-							int64planmodifier.RequiresReplace(),
+							// Volumes can only grow, a smaller volume needs a new server.
+							int64planmodifier.RequiresReplaceIf(
+								func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+									resp.RequiresReplace = !req.StateValue.IsNull() && !req.PlanValue.IsUnknown() && req.PlanValue.ValueInt64() < req.StateValue.ValueInt64()
+								},
+								"Shrinking the volume of a server requires replacement.",
+								"Shrinking the volume of a server requires replacement.",
+							),
 						},
 					},
 				},
@@ -250,12 +265,32 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					},
 				},
 			},
+			"power_state": schema.StringAttribute{
+				MarkdownDescription: "Enum: ```on``` ```off``` " +
+					"The power state of the server. When it is set, the server is started or stopped to match it and any change made outside of Terraform is reverted. When it is not set, the current power state is only reported. A resize keeps the power state the server had, unless `power_state` changes it. A server that is Shutdown, Paused, Suspended or Crashed is off, a server in a transitional state keeps its previous value.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(POWER_STATE_ON, POWER_STATE_OFF),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"allow_stop_for_update": schema.BoolAttribute{
+				MarkdownDescription: "Default: false" +
+					"If true, the server is allowed to be stopped and started again to apply changes that cannot be done while it is running, such as a new `flavor_id`. If false, those changes are rejected at plan time.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"last_updated": schema.StringAttribute{
 				MarkdownDescription: "The datetime of the last update.",
 				Computed:            true,
 			},
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
+				Update: true,
 			}),
 		},
 	}
@@ -281,6 +316,272 @@ func (r *ServerResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+// ModifyPlan validates the planned values against the Clouding API, so mistakes are
+// reported at plan time instead of failing in the middle of the apply.
+func (r *ServerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when the server is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	// The volume source is shared by the validations, it is only requested once when needed
+	source := &volumeSourceLookup{client: r.client, plan: req.Plan}
+
+	r.validateFlavor(ctx, req, resp)
+	r.validateResize(ctx, req, resp)
+	r.validateVolumeSize(ctx, req, resp, source)
+	r.validateAccessConfiguration(ctx, req, resp, source)
+}
+
+// volumeSource describes the image, snapshot or backup used as the volume source of a server.
+type volumeSource struct {
+	Source        string
+	ID            string
+	MinimumSizeGb int64
+	AccessMethods clouding.ImageAccessMethod
+}
+
+// volumeSourceLookup resolves the volume source of a plan the first time it is needed.
+type volumeSourceLookup struct {
+	client *clouding.API
+	plan   tfsdk.Plan
+	done   bool
+	source *volumeSource
+}
+
+// Get returns the volume source of the plan, or nil when it is unknown or cannot be
+// resolved. Problems resolving it are only reported to diags the first time.
+func (l *volumeSourceLookup) Get(ctx context.Context, diags *diag.Diagnostics) *volumeSource {
+	if l.done {
+		return l.source
+	}
+	l.done = true
+
+	var source, sourceID types.String
+	diags.Append(l.plan.GetAttribute(ctx, path.Root("volume").AtName("source"), &source)...)
+	diags.Append(l.plan.GetAttribute(ctx, path.Root("volume").AtName("id"), &sourceID)...)
+	if diags.HasError() || source.IsUnknown() || source.IsNull() || sourceID.IsUnknown() || sourceID.IsNull() {
+		return nil
+	}
+
+	result := volumeSource{
+		Source: source.ValueString(),
+		ID:     sourceID.ValueString(),
+	}
+	var err error
+	switch result.Source {
+	case "image":
+		var image clouding.Image
+		image, err = l.client.GetImageID(ctx, result.ID)
+		result.MinimumSizeGb = image.MinimumSizeGB
+		result.AccessMethods = image.AccessMethods
+	case "snapshot":
+		var snapshot clouding.Snapshot
+		snapshot, err = l.client.GetSnapshotID(ctx, result.ID)
+		result.MinimumSizeGb = snapshot.SizeGb
+		result.AccessMethods = snapshot.Image.AccessMethods
+	case "backup":
+		var backup clouding.Backup
+		backup, err = l.client.GetBackupID(ctx, result.ID)
+		result.MinimumSizeGb = backup.VolumeSizeGb
+		result.AccessMethods = backup.Image.AccessMethods
+	default:
+		return nil
+	}
+	if err != nil {
+		if clouding.IsNotFound(err) {
+			diags.AddAttributeError(
+				path.Root("volume").AtName("id"),
+				"Invalid Volume Source",
+				fmt.Sprintf("The %s %q used as volume source does not exist", result.Source, result.ID),
+			)
+			return nil
+		}
+		diags.AddWarning("Unable to validate volume source", fmt.Sprintf("Unable to read the %s %q, the volume and access configuration will be validated by the Clouding API: %s", result.Source, result.ID, err))
+		return nil
+	}
+
+	l.source = &result
+	return l.source
+}
+
+// validateFlavor rejects a flavor_id that is not in the list of available flavors.
+func (r *ServerResource) validateFlavor(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var flavorID, stateFlavorID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("flavor_id"), &flavorID)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("flavor_id"), &stateFlavorID)...)
+	}
+	if resp.Diagnostics.HasError() || flavorID.IsUnknown() || flavorID.IsNull() || flavorID.Equal(stateFlavorID) {
+		return
+	}
+
+	flavors, err := r.client.ListFlavors(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to validate flavor", fmt.Sprintf("Unable to list flavors, flavor_id %q will be validated by the Clouding API: %s", flavorID.ValueString(), err))
+		return
+	}
+
+	available := make([]string, 0, len(flavors))
+	for _, flavor := range flavors {
+		if flavor.ID == flavorID.ValueString() {
+			return
+		}
+		available = append(available, flavor.ID)
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("flavor_id"),
+		"Invalid Flavor",
+		fmt.Sprintf("Flavor %q does not exist, available flavors are: %s", flavorID.ValueString(), strings.Join(available, ", ")),
+	)
+}
+
+// validateResize rejects a flavor_id change of an existing server unless allow_stop_for_update
+// is set, because resizing may power-cycle the server. A flavor_id only known at apply time
+// is checked by Update.
+func (r *ServerResource) validateResize(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var flavorID, stateFlavorID types.String
+	var allowStop types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("flavor_id"), &flavorID)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("flavor_id"), &stateFlavorID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_stop_for_update"), &allowStop)...)
+	if resp.Diagnostics.HasError() || flavorID.IsUnknown() || flavorID.Equal(stateFlavorID) || allowStop.ValueBool() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("flavor_id"),
+		"Server Resize Not Allowed",
+		fmt.Sprintf("Changing flavor_id from %q to %q resizes the server, which may stop and start it. Set allow_stop_for_update = true to allow it.", stateFlavorID.ValueString(), flavorID.ValueString()),
+	)
+}
+
+// validateVolumeSize rejects a volume.ssd_gb that is not an allowed volume size or
+// is smaller than the image, snapshot or backup used as source.
+func (r *ServerResource) validateVolumeSize(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, lookup *volumeSourceLookup) {
+	sizePath := path.Root("volume").AtName("ssd_gb")
+
+	var size, stateSize types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, sizePath, &size)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, sizePath, &stateSize)...)
+	}
+	if resp.Diagnostics.HasError() || size.IsUnknown() || size.IsNull() || size.Equal(stateSize) {
+		return
+	}
+
+	sizes, err := r.client.ListVolumeSizes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to validate volume size", fmt.Sprintf("Unable to list volume sizes, ssd_gb %d will be validated by the Clouding API: %s", size.ValueInt64(), err))
+		return
+	}
+	allowed := make([]string, 0, len(sizes))
+	found := len(sizes) == 0
+	for _, volumeSize := range sizes {
+		found = found || volumeSize.SizeGb == size.ValueInt64()
+		allowed = append(allowed, strconv.FormatInt(volumeSize.SizeGb, 10))
+	}
+	if !found {
+		resp.Diagnostics.AddAttributeError(
+			sizePath,
+			"Invalid Volume Size",
+			fmt.Sprintf("Volume size %d is not allowed, allowed sizes in gigabytes are: %s", size.ValueInt64(), strings.Join(allowed, ", ")),
+		)
+		return
+	}
+
+	source := lookup.Get(ctx, &resp.Diagnostics)
+	if source != nil && size.ValueInt64() < source.MinimumSizeGb {
+		resp.Diagnostics.AddAttributeError(
+			sizePath,
+			"Invalid Volume Size",
+			fmt.Sprintf("Volume size %d is smaller than the %d gigabytes of the %s %q", size.ValueInt64(), source.MinimumSizeGb, source.Source, source.ID),
+		)
+	}
+}
+
+// validateAccessConfiguration rejects an access_configuration that is not supported
+// by the access methods of the image the volume is created from.
+func (r *ServerResource) validateAccessConfiguration(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, lookup *volumeSourceLookup) {
+	sshKeyPath := path.Root("access_configuration").AtName("ssh_key_id")
+	passwordPath := path.Root("access_configuration").AtName("password")
+
+	var sshKeyID, password, stateSshKeyID, statePassword types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, sshKeyPath, &sshKeyID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, passwordPath, &password)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, sshKeyPath, &stateSshKeyID)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, passwordPath, &statePassword)...)
+	}
+	if resp.Diagnostics.HasError() || password.IsUnknown() || (sshKeyID.Equal(stateSshKeyID) && password.Equal(statePassword)) {
+		return
+	}
+
+	source := lookup.Get(ctx, &resp.Diagnostics)
+	if source == nil {
+		return
+	}
+
+	// The ssh key can be unknown when it is created in the same apply
+	hasSshKey := sshKeyID.IsUnknown() || sshKeyID.ValueString() != ""
+	hasPassword := password.ValueString() != ""
+	name := fmt.Sprintf("%s %q", source.Source, source.ID)
+	errCount := resp.Diagnostics.ErrorsCount()
+
+	switch source.AccessMethods.SshKey {
+	case "not-supported":
+		if hasSshKey {
+			resp.Diagnostics.AddAttributeError(sshKeyPath, "Invalid Access Configuration", fmt.Sprintf("The %s does not support SSH key access, ssh_key_id must not be set", name))
+		}
+	case "required", "required-with-private-key":
+		if !hasSshKey {
+			resp.Diagnostics.AddAttributeError(sshKeyPath, "Invalid Access Configuration", fmt.Sprintf("The %s requires SSH key access, ssh_key_id must be set", name))
+		} else if source.AccessMethods.SshKey == "required-with-private-key" && !sshKeyID.IsUnknown() {
+			r.validateSshKeyPrivateKey(ctx, resp, sshKeyID.ValueString(), name)
+		}
+	}
+
+	switch source.AccessMethods.Password {
+	case "not-supported":
+		if hasPassword {
+			resp.Diagnostics.AddAttributeError(passwordPath, "Invalid Access Configuration", fmt.Sprintf("The %s does not support password access, password must not be set", name))
+		}
+	case "required":
+		if !hasPassword {
+			resp.Diagnostics.AddAttributeError(passwordPath, "Invalid Access Configuration", fmt.Sprintf("The %s requires password access, password must be set", name))
+		}
+	}
+
+	// Only when no access method is required, otherwise the error is already reported
+	if !hasSshKey && !hasPassword && resp.Diagnostics.ErrorsCount() == errCount {
+		resp.Diagnostics.AddAttributeError(path.Root("access_configuration"), "Invalid Access Configuration", "Either ssh_key_id or password must be set to access the server")
+	}
+}
+
+// validateSshKeyPrivateKey rejects an SSH key whose private key is not stored by Clouding.
+func (r *ServerResource) validateSshKeyPrivateKey(ctx context.Context, resp *resource.ModifyPlanResponse, sshKeyID, name string) {
+	sshKeyPath := path.Root("access_configuration").AtName("ssh_key_id")
+
+	sshKey, err := r.client.GetSshKeyID(ctx, sshKeyID)
+	if err != nil {
+		if clouding.IsNotFound(err) {
+			resp.Diagnostics.AddAttributeError(sshKeyPath, "Invalid Access Configuration", fmt.Sprintf("The SSH key %q does not exist", sshKeyID))
+			return
+		}
+		resp.Diagnostics.AddWarning("Unable to validate SSH key", fmt.Sprintf("Unable to read the SSH key %q, it will be validated by the Clouding API: %s", sshKeyID, err))
+		return
+	}
+
+	if !sshKey.HasPrivateKey {
+		resp.Diagnostics.AddAttributeError(sshKeyPath, "Invalid Access Configuration", fmt.Sprintf("The %s requires an SSH key with its private key stored in Clouding, the SSH key %q has no private key", name, sshKeyID))
+	}
+}
+
 func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan ServerResourceModel
 
@@ -340,7 +641,7 @@ func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest,
 		UserData:                      plan.UserData.ValueString(),
 		BackupPreference:              backupPreference,
 	}
-	err := r.client.CreateServer(&server)
+	err := r.client.CreateServer(ctx, &server)
 	if err != nil {
 		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to create server, got error: %s", err))
 		return
@@ -352,9 +653,8 @@ func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Wait for server action to complete
-	// Check the status of the action every 5 seconds, timeout after either 20 minutes or the value defined in the timeouts attribute
-	// There should be a better way to do this
-	err = r.client.WaitForAction(ctx, &server.Action, 5*time.Second)
+	// Check the status of the action starting every 5 seconds up to every 30 seconds, timeout after either 20 minutes or the value defined in the timeouts attribute
+	err = r.client.WaitForAction(ctx, &server.Action, 5*time.Second, clouding.WithMaxPollInterval(30*time.Second))
 	if err != nil {
 		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to wait for server action, got error: %s", err))
 		return
@@ -362,6 +662,17 @@ func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	tflog.Trace(ctx, fmt.Sprintf("Server resource action completed at: %s", server.Action.CompletedAt))
 
+	// New servers are running, stop it if it is wanted powered off
+	if plan.PowerState.ValueString() == POWER_STATE_OFF {
+		r.setPowerState(ctx, server.ID, POWER_STATE_OFF, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if plan.PowerState.IsUnknown() {
+		plan.PowerState = types.StringValue(POWER_STATE_ON)
+	}
+
 	// Save into the Terraform state.
 	plan.Id = types.StringValue(server.ID)
 	plan.Name = types.StringValue(server.Name)
@@ -435,8 +746,13 @@ func (r *ServerResource) Read(ctx context.Context, req resource.ReadRequest, res
 		Volume:              volume,
 		AccessConfiguration: accessConfiguration,
 	}
-	err := r.client.GetServerID(&server)
+	err := r.client.GetServerID(ctx, &server)
 	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Server %s not found, removing it from the state", state.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server, got error: %s", err))
 		return
 	}
@@ -446,7 +762,7 @@ func (r *ServerResource) Read(ctx context.Context, req resource.ReadRequest, res
 	state.Name = types.StringValue(server.Name)
 	state.Hostname = types.StringValue(server.Hostname)
 	state.FlavorID = types.StringValue(server.FlavorID)
-	state.FirewallID = types.StringValue(server.FirewallID)
+	state.FirewallID = serverFirewallID(state.FirewallID, server)
 	if server.AccessConfiguration != nil {
 		state.AccessConfiguration = &AccessConfigurationModel{
 			SshKeyID:     types.StringValue(server.AccessConfiguration.SshKeyID),
@@ -464,6 +780,7 @@ func (r *ServerResource) Read(ctx context.Context, req resource.ReadRequest, res
 	state.EnablePrivateNetwork = types.BoolValue(server.EnablePrivateNetwork)
 	state.EnableStrictAntiDDoSFiltering = types.BoolValue(server.EnableStrictAntiDDoSFiltering)
 	state.UserData = types.StringValue(server.UserData)
+	state.PowerState = powerStateValue(state.PowerState, server.PowerState)
 	if server.BackupPreference != nil {
 		state.BackupPreference = &BackupPreferenceModel{
 			Slots:     types.Int64Value(server.BackupPreference.Slots),
@@ -478,21 +795,105 @@ func (r *ServerResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan ServerResourceModel
+	var plan, state ServerResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Update Server on the Clouding API
-	err := r.client.UpdateServerName(plan.Id.ValueString(), plan.Name.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to update server, got error: %s", err))
+	// timeout
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Update Server on the Clouding API
+	if !plan.Name.Equal(state.Name) {
+		err := r.client.UpdateServerName(ctx, plan.Id.ValueString(), plan.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to update server, got error: %s", err))
+			return
+		}
+	}
+
+	resized := false
+	if !plan.FlavorID.Equal(state.FlavorID) {
+		// The plan is already rejected without allow_stop_for_update, this only guards the apply
+		if !plan.AllowStopForUpdate.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root("flavor_id"), "Server Resize Not Allowed", "Changing flavor_id requires allow_stop_for_update = true")
+			return
+		}
+
+		action, err := r.client.ResizeServer(ctx, plan.Id.ValueString(), plan.FlavorID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to resize server, got error: %s", err))
+			return
+		}
+		err = r.client.WaitForAction(ctx, &action, 5*time.Second, clouding.WithMaxPollInterval(30*time.Second))
+		if err != nil {
+			resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to wait for server resize action, got error: %s", err))
+			return
+		}
+
+		tflog.Trace(ctx, fmt.Sprintf("Server resize action completed at: %s", action.CompletedAt))
+		resized = true
+	}
+
+	volumeResized := plan.Volume != nil && state.Volume != nil && !plan.Volume.SsdGB.Equal(state.Volume.SsdGB)
+	if volumeResized {
+		action, err := r.client.ResizeServerVolume(ctx, plan.Id.ValueString(), plan.Volume.SsdGB.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to resize server volume, got error: %s", err))
+			return
+		}
+		err = r.client.WaitForAction(ctx, &action, 5*time.Second, clouding.WithMaxPollInterval(30*time.Second))
+		if err != nil {
+			resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to wait for server volume resize action, got error: %s", err))
+			return
+		}
+
+		tflog.Trace(ctx, fmt.Sprintf("Server volume resize action completed at: %s", action.CompletedAt))
+		resized = true
+	}
+
+	// Refresh the server once it is resized, the volume size is reported by the API and the
+	// resize may have started or stopped the server
+	powerState := state.PowerState
+	if resized {
+		server := clouding.Server{ID: plan.Id.ValueString()}
+		err := r.client.GetServerID(ctx, &server)
+		if err != nil {
+			resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to read server, got error: %s", err))
+			return
+		}
+		if volumeResized {
+			plan.Volume.SsdGB = types.Int64Value(server.VolumeSizeGb)
+		}
+		powerState = powerStateValue(powerState, server.PowerState)
+	}
+	if !plan.FirewallID.Equal(state.FirewallID) {
+		r.replaceFirewall(ctx, plan.Id.ValueString(), state.FirewallID.ValueString(), plan.FirewallID.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The power state is changed last, so the one left by a resize is corrected too
+	if !plan.PowerState.IsUnknown() && !plan.PowerState.IsNull() && !plan.PowerState.Equal(powerState) {
+		r.setPowerState(ctx, plan.Id.ValueString(), plan.PowerState.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	// Save updated data into Terraform state
@@ -510,8 +911,12 @@ func (r *ServerResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	// Delete Server on the Clouding API
-	action, err := r.client.DeleteServer(state.Id.ValueString())
+	action, err := r.client.DeleteServer(ctx, state.Id.ValueString())
 	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Server %s already deleted", state.Id.ValueString()))
+			return
+		}
 		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to delete server, got error: %s", err))
 		return
 	}
@@ -522,6 +927,98 @@ func (r *ServerResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+// replaceFirewall attaches the new firewall to the server before detaching the old one,
+// so the server is never left without a firewall, and waits for both changes to be applied.
+func (r *ServerResource) replaceFirewall(ctx context.Context, id, oldFirewallID, newFirewallID string, diags *diag.Diagnostics) {
+	err := r.client.AttachFirewall(ctx, newFirewallID, id)
+	if err != nil {
+		diags.AddError("Clouding API Error", fmt.Sprintf("Unable to attach firewall %s, got error: %s", newFirewallID, err))
+		return
+	}
+	err = r.client.WaitForServerFirewalls(ctx, id, 5*time.Second, clouding.WithMaxPollInterval(30*time.Second))
+	if err != nil {
+		diags.AddError("Clouding API Error", fmt.Sprintf("Unable to wait for server firewalls, got error: %s", err))
+		return
+	}
+
+	if oldFirewallID == "" {
+		return
+	}
+	err = r.client.DetachFirewall(ctx, oldFirewallID, id)
+	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Firewall %s already deleted", oldFirewallID))
+			return
+		}
+		diags.AddError("Clouding API Error", fmt.Sprintf("Unable to detach firewall %s, got error: %s", oldFirewallID, err))
+		return
+	}
+	err = r.client.WaitForServerFirewalls(ctx, id, 5*time.Second, clouding.WithMaxPollInterval(30*time.Second))
+	if err != nil {
+		diags.AddError("Clouding API Error", fmt.Sprintf("Unable to wait for server firewalls, got error: %s", err))
+		return
+	}
+}
+
+// serverFirewallID reconciles the firewall_id of the state with the firewalls attached to
+// the server, which can have other firewalls attached by clouding_firewall_attachment.
+// A firewall still pending to be applied counts as attached. An imported server takes its
+// first firewall, otherwise an empty value reports that the firewall was detached, without
+// adopting one of the others.
+func serverFirewallID(firewallID types.String, server clouding.Server) types.String {
+	firewallIDs := make([]string, 0, len(server.Firewalls)+len(server.PendingFirewalls))
+	for _, firewall := range server.Firewalls {
+		firewallIDs = append(firewallIDs, firewall.ID)
+	}
+	firewallIDs = append(firewallIDs, server.PendingFirewalls...)
+
+	if firewallID.IsNull() || firewallID.IsUnknown() {
+		if len(firewallIDs) > 0 {
+			return types.StringValue(firewallIDs[0])
+		}
+		return types.StringNull()
+	}
+
+	if slices.Contains(firewallIDs, firewallID.ValueString()) {
+		return firewallID
+	}
+	return types.StringValue("")
+}
+
+// setPowerState starts or stops the server and waits until it is in the wanted power state.
+func (r *ServerResource) setPowerState(ctx context.Context, id, powerState string, diags *diag.Diagnostics) {
+	powerAction := r.client.StartServer
+	if powerState == POWER_STATE_OFF {
+		powerAction = r.client.StopServer
+	}
+
+	action, err := powerAction(ctx, id)
+	if err != nil {
+		diags.AddError("Clouding API Error", fmt.Sprintf("Unable to power %s server, got error: %s", powerState, err))
+		return
+	}
+	err = r.client.WaitForAction(ctx, &action, 5*time.Second, clouding.WithMaxPollInterval(30*time.Second))
+	if err != nil {
+		diags.AddError("Clouding API Error", fmt.Sprintf("Unable to wait for server power %s action, got error: %s", powerState, err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Server power %s action completed at: %s", powerState, action.CompletedAt))
+}
+
+// powerStateValue maps the power state reported by the Clouding API to the power_state
+// attribute. A server in a transitional or unknown state, e.g. while it is starting, keeps
+// the prior value until it settles.
+func powerStateValue(prior types.String, powerState string) types.String {
+	switch powerState {
+	case clouding.POWER_STATE_RUNNING:
+		return types.StringValue(POWER_STATE_ON)
+	case clouding.POWER_STATE_SHUTDOWN, clouding.POWER_STATE_PAUSED, clouding.POWER_STATE_SUSPENDED, clouding.POWER_STATE_CRASHED:
+		return types.StringValue(POWER_STATE_OFF)
+	}
+	return prior
+}
+
 func (r *ServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
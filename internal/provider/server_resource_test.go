@@ -1,10 +1,26 @@
 package provider_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccServer(t *testing.T) {
@@ -64,3 +80,748 @@ resource "clouding_server" "test" {
 }
 `, name, hostname)
 }
+
+func TestServerResourceNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := &provider.ServerResource{}
+	testConfigureResource(t, r, testNotFoundHandler(t))
+	state := testResourceState(t, r, &provider.ServerResourceModel{
+		Id:       types.StringValue("Q7y1OZWlknXmk6l3"),
+		Name:     types.StringValue("testacc"),
+		Hostname: types.StringValue("testacc01"),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{"create": types.StringType, "update": types.StringType}),
+		},
+	})
+
+	readResp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull())
+
+	deleteResp := fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, &deleteResp)
+	assert.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+}
+
+// testHandleJSON registers a handler answering the pattern with the status and the JSON body.
+func testHandleJSON(t *testing.T, mux *http.ServeMux, pattern string, status int, body string) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, err := w.Write([]byte(body))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	})
+}
+
+// testHandleServerOperations registers the operations of the server Q7y1OZWlknXmk6l3, they
+// all start the action K9xnYDvBQ4mOlJRa which is already completed when it is requested.
+func testHandleServerOperations(t *testing.T, mux *http.ServeMux, operations ...string) {
+	for _, operation := range operations {
+		testHandleJSON(t, mux, "/v1/servers/Q7y1OZWlknXmk6l3/"+operation, http.StatusAccepted,
+			fmt.Sprintf(`{"id": "K9xnYDvBQ4mOlJRa", "status": "inProgress", "type": %q, "resourceId": "Q7y1OZWlknXmk6l3"}`, operation))
+	}
+	testHandleJSON(t, mux, "/v1/actions/K9xnYDvBQ4mOlJRa", http.StatusOK,
+		`{"id": "K9xnYDvBQ4mOlJRa", "status": "completed", "resourceId": "Q7y1OZWlknXmk6l3"}`)
+}
+
+// testFlavorsHandler answers the flavors list as the Clouding API does and counts the requests made.
+func testFlavorsHandler(t *testing.T, requests *int) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sizes/flavors", func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{
+		  "flavors": [
+		    {"id": "0.5x1", "vCores": 0.5, "ramGb": 1, "pricePerHour": 0.00685, "pricePerMonthApprox": 5},
+		    {"id": "1x2", "vCores": 1, "ramGb": 2, "pricePerHour": 0.0137, "pricePerMonthApprox": 10}
+		  ],
+		  "links": {"next": null}
+		}`))
+		if err != nil {
+			t.Errorf("error writing response: %s", err)
+		}
+	})
+	mux.Handle("/", testNotFoundHandler(t))
+	return mux
+}
+
+// testVolumeSizesHandler answers the allowed volume sizes and the volume sources of a server as the Clouding API does.
+func testVolumeSizesHandler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	testHandleJSON(t, mux, "/v1/sizes/volumes", http.StatusOK, `{
+	  "volumeSizes": [{"sizeGb": 5}, {"sizeGb": 10}, {"sizeGb": 20}, {"sizeGb": 25}, {"sizeGb": 30}],
+	  "links": {"next": null}
+	}`)
+	testHandleJSON(t, mux, "/v1/images/d3mKbx4zd3XEQaqP", http.StatusOK,
+		`{"id": "d3mKbx4zd3XEQaqP", "name": "Windows Server 2022 (English 64Bit)", "minimumSizeGb": 25}`)
+	testHandleJSON(t, mux, "/v1/snapshots/xQ3jbNeJ2lDPqVWg", http.StatusOK, `{"id": "xQ3jbNeJ2lDPqVWg", "sizeGb": 20}`)
+	testHandleJSON(t, mux, "/v1/backups/86EAL1xB769Z4q2w", http.StatusOK, `{"id": "86EAL1xB769Z4q2w", "volumeSizeGb": 10}`)
+	mux.Handle("/", testNotFoundHandler(t))
+	return mux
+}
+
+// testAccessConfigurationHandler answers the images and SSH keys used to access a server as the Clouding API does.
+func testAccessConfigurationHandler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	testHandleJSON(t, mux, "/v1/images/d3mKbx4zd3XEQaqP", http.StatusOK, `{
+	  "id": "d3mKbx4zd3XEQaqP",
+	  "name": "Windows Server 2022 (English 64Bit)",
+	  "minimumSizeGb": 25,
+	  "accessMethods": {"sshKey": "not-supported", "password": "required"}
+	}`)
+	testHandleJSON(t, mux, "/v1/images/lo1qJ9oZb1xGMEgD", http.StatusOK, `{
+	  "id": "lo1qJ9oZb1xGMEgD",
+	  "name": "CelestiaOS 2.04 (64 Bit)",
+	  "minimumSizeGb": 5,
+	  "accessMethods": {"sshKey": "required-with-private-key", "password": "not-supported"}
+	}`)
+	testHandleJSON(t, mux, "/v1/keypairs/Dd8v0nXJ1924rayY", http.StatusOK, `{"id": "Dd8v0nXJ1924rayY", "name": "with-private-key", "hasPrivateKey": true}`)
+	testHandleJSON(t, mux, "/v1/keypairs/mawqYZWOojWQyOV0", http.StatusOK, `{"id": "mawqYZWOojWQyOV0", "name": "public-only", "hasPrivateKey": false}`)
+	mux.Handle("/", testNotFoundHandler(t))
+	return mux
+}
+
+// testServerResizeHandler answers as the Clouding API for the server Q7y1OZWlknXmk6l3 resized to
+// the flavor 1x2 with a volume of 30 gigabytes.
+func testServerResizeHandler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	testHandleJSON(t, mux, "/v1/servers/Q7y1OZWlknXmk6l3", http.StatusOK,
+		`{"id": "Q7y1OZWlknXmk6l3", "name": "testacc", "hostname": "testacc01", "flavor": "1x2", "volumeSizeGb": 30}`)
+	testHandleServerOperations(t, mux, "resize")
+	mux.Handle("/", testNotFoundHandler(t))
+	return mux
+}
+
+// testServerPowerHandler answers as the Clouding API for the server Q7y1OZWlknXmk6l3 in the power state.
+func testServerPowerHandler(t *testing.T, powerState string) http.Handler {
+	mux := http.NewServeMux()
+	testHandleJSON(t, mux, "/v1/servers/Q7y1OZWlknXmk6l3", http.StatusOK,
+		fmt.Sprintf(`{"id": "Q7y1OZWlknXmk6l3", "name": "testacc", "hostname": "testacc01", "flavor": "1x2", "powerState": %q}`, powerState))
+	testHandleServerOperations(t, mux, "resize", "start", "stop")
+	mux.Handle("/", testNotFoundHandler(t))
+	return mux
+}
+
+// testServerFirewallHandler answers as the Clouding API for the server Q7y1OZWlknXmk6l3 with the
+// firewall w1EqyGl4zXgm6kbj attached and the given pending firewalls, w1EqyGl4zXgm6kbj can be
+// attached again and mYaRvlx1OmXApk6N detached.
+func testServerFirewallHandler(t *testing.T, pendingFirewalls ...string) http.Handler {
+	pending, err := json.Marshal(append([]string{}, pendingFirewalls...))
+	if err != nil {
+		t.Fatalf("error encoding pending firewalls: %s", err)
+	}
+	mux := http.NewServeMux()
+	testHandleJSON(t, mux, "/v1/servers/Q7y1OZWlknXmk6l3", http.StatusOK,
+		fmt.Sprintf(`{"id": "Q7y1OZWlknXmk6l3", "name": "testacc", "hostname": "testacc01", "flavor": "1x2", "pendingFirewalls": %s, "firewalls": [{"id": "w1EqyGl4zXgm6kbj"}]}`, pending))
+	mux.HandleFunc("/v1/firewalls/w1EqyGl4zXgm6kbj/attach", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/v1/firewalls/mYaRvlx1OmXApk6N/detach", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.Handle("/", testNotFoundHandler(t))
+	return mux
+}
+
+func testServerModel(flavorID string) *provider.ServerResourceModel {
+	return &provider.ServerResourceModel{
+		Id:       types.StringValue("Q7y1OZWlknXmk6l3"),
+		Name:     types.StringValue("testacc"),
+		Hostname: types.StringValue("testacc01"),
+		FlavorID: types.StringValue(flavorID),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{"create": types.StringType, "update": types.StringType}),
+		},
+	}
+}
+
+func TestServerResourceModifyPlanFlavor(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		state    *provider.ServerResourceModel
+		plan     *provider.ServerResourceModel
+		requests int
+		err      string
+	}{
+		"create with valid flavor": {
+			plan:     testServerModel("1x2"),
+			requests: 1,
+		},
+		"create with unknown flavor": {
+			plan:     testServerModel("3x3"),
+			requests: 1,
+			err:      `Flavor "3x3" does not exist, available flavors are: 0.5x1, 1x2`,
+		},
+		"unchanged flavor": {
+			state:    testServerModel("3x3"),
+			plan:     testServerModel("3x3"),
+			requests: 0,
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			requests := 0
+			r := &provider.ServerResource{}
+			testConfigureResource(t, r, testFlavorsHandler(t, &requests))
+
+			plan := testResourcePlan(t, r, test.plan)
+			state := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+			if test.state != nil {
+				state = testResourceState(t, r, test.state)
+			}
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+			assert.Equal(t, test.requests, requests)
+			if test.err == "" {
+				assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+				return
+			}
+			assert.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, test.err, resp.Diagnostics.Errors()[0].Detail())
+		})
+	}
+}
+
+func TestServerResourceModifyPlanFlavorListError(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := &provider.ServerResource{}
+	testConfigureResource(t, r, testNotFoundHandler(t))
+
+	plan := testResourcePlan(t, r, testServerModel("1x2"))
+	state := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+
+	resp := fwresource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Len(t, resp.Diagnostics.Warnings(), 1)
+}
+
+func TestServerResourceModifyPlanResize(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		flavorID  types.String
+		allowStop bool
+		err       string
+	}{
+		"allowed": {
+			flavorID:  types.StringValue("1x2"),
+			allowStop: true,
+		},
+		"not allowed": {
+			flavorID: types.StringValue("1x2"),
+			err:      `Changing flavor_id from "0.5x1" to "1x2" resizes the server, which may stop and start it. Set allow_stop_for_update = true to allow it.`,
+		},
+		"known at apply time": {
+			flavorID: types.StringUnknown(),
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			requests := 0
+			r := &provider.ServerResource{}
+			testConfigureResource(t, r, testFlavorsHandler(t, &requests))
+
+			model := testServerModel("1x2")
+			model.FlavorID = test.flavorID
+			model.AllowStopForUpdate = types.BoolValue(test.allowStop)
+			plan := testResourcePlan(t, r, model)
+			state := testResourceState(t, r, testServerModel("0.5x1"))
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+			if test.err == "" {
+				assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+				return
+			}
+			assert.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, test.err, resp.Diagnostics.Errors()[0].Detail())
+		})
+	}
+}
+
+func TestServerResourceUpdateResize(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := &provider.ServerResource{}
+	testConfigureResource(t, r, testServerResizeHandler(t))
+
+	model := testServerModel("1x2")
+	model.AllowStopForUpdate = types.BoolValue(true)
+	plan := testResourcePlan(t, r, model)
+	state := testResourceState(t, r, testServerModel("0.5x1"))
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var flavorID types.String
+	resp.State.GetAttribute(ctx, path.Root("flavor_id"), &flavorID)
+	assert.Equal(t, "1x2", flavorID.ValueString())
+}
+
+func TestServerResourceUpdateVolume(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := &provider.ServerResource{}
+	testConfigureResource(t, r, testServerResizeHandler(t))
+
+	model := testServerModel("0.5x1")
+	model.Volume = &provider.VolumeModel{
+		Source: types.StringValue("image"),
+		Id:     types.StringValue("wLQbN5nvg829JaeZ"),
+		SsdGB:  types.Int64Value(30),
+	}
+	plan := testResourcePlan(t, r, model)
+	model.Volume.SsdGB = types.Int64Value(20)
+	state := testResourceState(t, r, model)
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var size types.Int64
+	resp.State.GetAttribute(ctx, path.Root("volume").AtName("ssd_gb"), &size)
+	assert.Equal(t, int64(30), size.ValueInt64())
+}
+
+func TestServerResourceVolumeSizeRequiresReplace(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		state   types.Int64
+		plan    types.Int64
+		replace bool
+	}{
+		"create": {
+			state: types.Int64Null(),
+			plan:  types.Int64Value(20),
+		},
+		"grow": {
+			state: types.Int64Value(20),
+			plan:  types.Int64Value(30),
+		},
+		"shrink": {
+			state:   types.Int64Value(30),
+			plan:    types.Int64Value(20),
+			replace: true,
+		},
+	}
+
+	r := &provider.ServerResource{}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	volume := schemaResp.Schema.Attributes["volume"].(schema.SingleNestedAttribute)
+	ssdGb := volume.Attributes["ssd_gb"].(schema.Int64Attribute)
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			plan := testResourcePlan(t, r, testServerModel("1x2"))
+			state := testResourceState(t, r, testServerModel("1x2"))
+			if test.state.IsNull() {
+				state = tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+			}
+			req := planmodifier.Int64Request{
+				Path:        path.Root("volume").AtName("ssd_gb"),
+				Plan:        plan,
+				PlanValue:   test.plan,
+				State:       state,
+				StateValue:  test.state,
+				ConfigValue: test.plan,
+			}
+			resp := planmodifier.Int64Response{PlanValue: test.plan}
+			for _, modifier := range ssdGb.PlanModifiers {
+				modifier.PlanModifyInt64(ctx, req, &resp)
+			}
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, test.replace, resp.RequiresReplace)
+		})
+	}
+}
+
+func TestServerResourceReadPowerState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		prior      types.String
+		powerState string
+		expected   types.String
+	}{
+		"running": {
+			prior:      types.StringValue("off"),
+			powerState: "Running",
+			expected:   types.StringValue("on"),
+		},
+		"shutdown": {
+			prior:      types.StringValue("on"),
+			powerState: "Shutdown",
+			expected:   types.StringValue("off"),
+		},
+		"crashed": {
+			prior:      types.StringValue("on"),
+			powerState: "Crashed",
+			expected:   types.StringValue("off"),
+		},
+		"transitional": {
+			prior:      types.StringValue("on"),
+			powerState: "NoState",
+			expected:   types.StringValue("on"),
+		},
+		"imported while transitional": {
+			prior:      types.StringNull(),
+			powerState: "Starting",
+			expected:   types.StringNull(),
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := &provider.ServerResource{}
+			testConfigureResource(t, r, testServerPowerHandler(t, test.powerState))
+
+			model := testServerModel("1x2")
+			model.PowerState = test.prior
+			state := testResourceState(t, r, model)
+
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var powerState types.String
+			resp.State.GetAttribute(ctx, path.Root("power_state"), &powerState)
+			assert.Equal(t, test.expected, powerState)
+		})
+	}
+}
+
+func TestServerResourceUpdatePowerState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := &provider.ServerResource{}
+	testConfigureResource(t, r, testServerPowerHandler(t, "Shutdown"))
+
+	model := testServerModel("1x2")
+	model.PowerState = types.StringValue("on")
+	state := testResourceState(t, r, model)
+	model.PowerState = types.StringValue("off")
+	plan := testResourcePlan(t, r, model)
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var powerState types.String
+	resp.State.GetAttribute(ctx, path.Root("power_state"), &powerState)
+	assert.Equal(t, "off", powerState.ValueString())
+}
+
+func TestServerResourceUpdateResizePowerState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		powerState string
+		requests   []string
+	}{
+		"started by the resize": {
+			powerState: "Running",
+			requests: []string{
+				"POST /v1/servers/Q7y1OZWlknXmk6l3/resize",
+				"GET /v1/actions/K9xnYDvBQ4mOlJRa",
+				"GET /v1/servers/Q7y1OZWlknXmk6l3",
+				"POST /v1/servers/Q7y1OZWlknXmk6l3/stop",
+				"GET /v1/actions/K9xnYDvBQ4mOlJRa",
+			},
+		},
+		"stopped after the resize": {
+			powerState: "Shutdown",
+			requests: []string{
+				"POST /v1/servers/Q7y1OZWlknXmk6l3/resize",
+				"GET /v1/actions/K9xnYDvBQ4mOlJRa",
+				"GET /v1/servers/Q7y1OZWlknXmk6l3",
+			},
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var mu sync.Mutex
+			var requests []string
+			handler := testServerPowerHandler(t, test.powerState)
+			r := &provider.ServerResource{}
+			testConfigureResource(t, r, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				mu.Unlock()
+				handler.ServeHTTP(w, r)
+			}))
+
+			model := testServerModel("0.5x1")
+			model.PowerState = types.StringValue("off")
+			state := testResourceState(t, r, model)
+			model.FlavorID = types.StringValue("1x2")
+			model.AllowStopForUpdate = types.BoolValue(true)
+			plan := testResourcePlan(t, r, model)
+
+			resp := fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, test.requests, requests)
+
+			var powerState types.String
+			resp.State.GetAttribute(ctx, path.Root("power_state"), &powerState)
+			assert.Equal(t, "off", powerState.ValueString())
+		})
+	}
+}
+
+func TestServerResourceReadFirewall(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		firewallID types.String
+		pending    []string
+		expected   types.String
+	}{
+		"attached": {
+			firewallID: types.StringValue("w1EqyGl4zXgm6kbj"),
+			expected:   types.StringValue("w1EqyGl4zXgm6kbj"),
+		},
+		"pending": {
+			firewallID: types.StringValue("mYaRvlx1OmXApk6N"),
+			pending:    []string{"mYaRvlx1OmXApk6N"},
+			expected:   types.StringValue("mYaRvlx1OmXApk6N"),
+		},
+		"detached outside of terraform": {
+			firewallID: types.StringValue("mYaRvlx1OmXApk6N"),
+			expected:   types.StringValue(""),
+		},
+		"imported": {
+			firewallID: types.StringNull(),
+			expected:   types.StringValue("w1EqyGl4zXgm6kbj"),
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := &provider.ServerResource{}
+			testConfigureResource(t, r, testServerFirewallHandler(t, test.pending...))
+
+			model := testServerModel("1x2")
+			model.FirewallID = test.firewallID
+			state := testResourceState(t, r, model)
+
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var firewallID types.String
+			resp.State.GetAttribute(ctx, path.Root("firewall_id"), &firewallID)
+			assert.Equal(t, test.expected, firewallID)
+		})
+	}
+}
+
+func TestServerResourceUpdateFirewall(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := &provider.ServerResource{}
+	testConfigureResource(t, r, testServerFirewallHandler(t))
+
+	model := testServerModel("1x2")
+	model.FirewallID = types.StringValue("mYaRvlx1OmXApk6N")
+	state := testResourceState(t, r, model)
+	model.FirewallID = types.StringValue("w1EqyGl4zXgm6kbj")
+	plan := testResourcePlan(t, r, model)
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var firewallID types.String
+	resp.State.GetAttribute(ctx, path.Root("firewall_id"), &firewallID)
+	assert.Equal(t, "w1EqyGl4zXgm6kbj", firewallID.ValueString())
+}
+
+func TestServerResourceModifyPlanVolumeSize(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		source string
+		id     string
+		size   int64
+		err    string
+	}{
+		"image": {
+			source: "image",
+			id:     "d3mKbx4zd3XEQaqP",
+			size:   25,
+		},
+		"smaller than image": {
+			source: "image",
+			id:     "d3mKbx4zd3XEQaqP",
+			size:   20,
+			err:    `Volume size 20 is smaller than the 25 gigabytes of the image "d3mKbx4zd3XEQaqP"`,
+		},
+		"smaller than snapshot": {
+			source: "snapshot",
+			id:     "xQ3jbNeJ2lDPqVWg",
+			size:   10,
+			err:    `Volume size 10 is smaller than the 20 gigabytes of the snapshot "xQ3jbNeJ2lDPqVWg"`,
+		},
+		"backup": {
+			source: "backup",
+			id:     "86EAL1xB769Z4q2w",
+			size:   10,
+		},
+		"not allowed size": {
+			source: "backup",
+			id:     "86EAL1xB769Z4q2w",
+			size:   12,
+			err:    "Volume size 12 is not allowed, allowed sizes in gigabytes are: 5, 10, 20, 25, 30",
+		},
+		"missing source": {
+			source: "image",
+			id:     "wLQbN5nvg829JaeZ",
+			size:   10,
+			err:    `The image "wLQbN5nvg829JaeZ" used as volume source does not exist`,
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := &provider.ServerResource{}
+			testConfigureResource(t, r, testVolumeSizesHandler(t))
+
+			model := testServerModel("1x2")
+			model.Volume = &provider.VolumeModel{
+				Source: types.StringValue(test.source),
+				Id:     types.StringValue(test.id),
+				SsdGB:  types.Int64Value(test.size),
+			}
+			plan := testResourcePlan(t, r, model)
+			state := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+			if test.err == "" {
+				assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+				return
+			}
+			assert.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, test.err, resp.Diagnostics.Errors()[0].Detail())
+		})
+	}
+}
+
+func TestServerResourceModifyPlanAccessConfiguration(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		image    string
+		sshKeyID types.String
+		password types.String
+		path     path.Path
+		err      string
+	}{
+		"password required": {
+			image:    "d3mKbx4zd3XEQaqP",
+			sshKeyID: types.StringValue(""),
+			password: types.StringValue("Sup3rS3cret!"),
+		},
+		"password missing": {
+			image:    "d3mKbx4zd3XEQaqP",
+			sshKeyID: types.StringValue(""),
+			password: types.StringNull(),
+			path:     path.Root("access_configuration").AtName("password"),
+			err:      `The image "d3mKbx4zd3XEQaqP" requires password access, password must be set`,
+		},
+		"ssh key not supported": {
+			image:    "d3mKbx4zd3XEQaqP",
+			sshKeyID: types.StringValue("Dd8v0nXJ1924rayY"),
+			password: types.StringValue("Sup3rS3cret!"),
+			path:     path.Root("access_configuration").AtName("ssh_key_id"),
+			err:      `The image "d3mKbx4zd3XEQaqP" does not support SSH key access, ssh_key_id must not be set`,
+		},
+		"ssh key with private key": {
+			image:    "lo1qJ9oZb1xGMEgD",
+			sshKeyID: types.StringValue("Dd8v0nXJ1924rayY"),
+			password: types.StringNull(),
+		},
+		"ssh key created in the same apply": {
+			image:    "lo1qJ9oZb1xGMEgD",
+			sshKeyID: types.StringUnknown(),
+			password: types.StringNull(),
+		},
+		"ssh key missing": {
+			image:    "lo1qJ9oZb1xGMEgD",
+			sshKeyID: types.StringValue(""),
+			password: types.StringNull(),
+			path:     path.Root("access_configuration").AtName("ssh_key_id"),
+			err:      `The image "lo1qJ9oZb1xGMEgD" requires SSH key access, ssh_key_id must be set`,
+		},
+		"ssh key without private key": {
+			image:    "lo1qJ9oZb1xGMEgD",
+			sshKeyID: types.StringValue("mawqYZWOojWQyOV0"),
+			password: types.StringNull(),
+			path:     path.Root("access_configuration").AtName("ssh_key_id"),
+			err:      `The image "lo1qJ9oZb1xGMEgD" requires an SSH key with its private key stored in Clouding, the SSH key "mawqYZWOojWQyOV0" has no private key`,
+		},
+		"password not supported": {
+			image:    "lo1qJ9oZb1xGMEgD",
+			sshKeyID: types.StringValue("Dd8v0nXJ1924rayY"),
+			password: types.StringValue("Sup3rS3cret!"),
+			path:     path.Root("access_configuration").AtName("password"),
+			err:      `The image "lo1qJ9oZb1xGMEgD" does not support password access, password must not be set`,
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := &provider.ServerResource{}
+			testConfigureResource(t, r, testAccessConfigurationHandler(t))
+
+			model := testServerModel("1x2")
+			model.Volume = &provider.VolumeModel{
+				Source: types.StringValue("image"),
+				Id:     types.StringValue(test.image),
+				SsdGB:  types.Int64Value(25),
+			}
+			model.AccessConfiguration = &provider.AccessConfigurationModel{
+				SshKeyID:     test.sshKeyID,
+				Password:     test.password,
+				SavePassword: types.BoolValue(false),
+			}
+			plan := testResourcePlan(t, r, model)
+			state := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+			if test.err == "" {
+				assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+				return
+			}
+			assert.Len(t, resp.Diagnostics.Errors(), 1, resp.Diagnostics)
+			assert.Equal(t, test.err, resp.Diagnostics.Errors()[0].Detail())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path().String(), test.path.String())
+		})
+	}
+}
@@ -162,7 +162,7 @@ func (d *SnapshotDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	snapshot, err := d.client.GetSnapshotID(state.Id.ValueString())
+	snapshot, err := d.client.GetSnapshotID(ctx, state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving Snapshot",
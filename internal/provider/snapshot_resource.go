@@ -3,15 +3,20 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
 )
 
@@ -30,11 +35,15 @@ type SnapShotResource struct {
 
 // SnapShotResourceModel describes the resource data model.
 type SnapShotResourceModel struct {
-	Id             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	Description    types.String `tfsdk:"description"`
-	ShutDownServer types.Bool   `tfsdk:"shutdown_server"`
-	LastUpdated    types.String `tfsdk:"last_updated"`
+	Id             types.String   `tfsdk:"id"`
+	ServerID       types.String   `tfsdk:"server_id"`
+	Name           types.String   `tfsdk:"name"`
+	Description    types.String   `tfsdk:"description"`
+	ShutDownServer types.Bool     `tfsdk:"shutdown_server"`
+	SizeGb         types.Int64    `tfsdk:"size_gb"`
+	CreatedAt      types.String   `tfsdk:"created_at"`
+	LastUpdated    types.String   `tfsdk:"last_updated"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *SnapShotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -59,7 +68,14 @@ func (r *SnapShotResource) Schema(ctx context.Context, req resource.SchemaReques
 				Required:            true,
 				MarkdownDescription: "The server id to create the snapshot.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					// The API does not return the server id, an imported snapshot takes it from the configuration.
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"Changing the server id of a snapshot requires replacement, unless the snapshot was imported.",
+						"Changing the server id of a snapshot requires replacement, unless the snapshot was imported.",
+					),
 				},
 			},
 			"name": schema.StringAttribute{
@@ -78,10 +94,32 @@ func (r *SnapShotResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"shutdown_server": schema.BoolAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Shutdown the server before creating the snapshot. This is recommended as it increases stability.",
+				MarkdownDescription: "Default: false" + "Shutdown the server before creating the snapshot. This is recommended as it increases stability. It only applies when the snapshot is created.",
 				Default:             booldefault.StaticBool(false),
 			},
+			"size_gb": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The size of the Snapshot in gigabytes.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date and time when the Snapshot was created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The Snapshot datetime update",
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+			}),
 		},
 	}
 }
@@ -114,22 +152,159 @@ func (r *SnapShotResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	//snapshot := clouding.Snapshot{
-	//	Name:           plan.Name.ValueString(),
-	//	Description:    plan.Description.ValueString(),
-	//	ShutDownServer: true,
-	//}
+	// timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	action, err := r.client.CreateSnapshot(ctx, plan.ServerID.ValueString(), plan.Name.ValueString(), plan.Description.ValueString(), plan.ShutDownServer.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to create snapshot, got error: %s", err))
+		return
+	}
+
+	if action.ResourceID == "" {
+		resp.Diagnostics.AddError("Clouding API Error", "Snapshot Action resource ID response is empty")
+		return
+	}
+
+	// Save the snapshot before waiting, so a failed wait does not leave an untracked snapshot.
+	// The computed values are left empty until the snapshot is read.
+	plan.Id = types.StringValue(action.ResourceID)
+	plan.SizeGb = types.Int64Null()
+	plan.CreatedAt = types.StringNull()
+	plan.LastUpdated = types.StringNull()
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = r.client.WaitForAction(ctx, &action, 5*time.Second, clouding.WithMaxPollInterval(30*time.Second))
+	if err != nil {
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to wait for snapshot action, got error: %s", err))
+		return
+	}
+
+	snapshot, err := r.client.GetSnapshotID(ctx, action.ResourceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to read snapshot, got error: %s", err))
+		return
+	}
+
+	// Save into the Terraform state.
+	plan.Id = types.StringValue(snapshot.ID)
+	plan.Name = types.StringValue(snapshot.Name)
+	plan.Description = types.StringValue(snapshot.Description)
+	plan.SizeGb = types.Int64Value(snapshot.SizeGb)
+	plan.CreatedAt = types.StringValue(snapshot.CreatedAt)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Snapshot resource created")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *SnapShotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SnapShotResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, err := r.client.GetSnapshotID(ctx, state.Id.ValueString())
+	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Snapshot %s not found, removing it from the state", state.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Clouding Client Error", fmt.Sprintf("Unable to read snapshot id, got error: %s", err))
+		return
+	}
 
+	state.Name = types.StringValue(snapshot.Name)
+	state.Description = types.StringValue(snapshot.Description)
+	state.SizeGb = types.Int64Value(snapshot.SizeGb)
+	state.CreatedAt = types.StringValue(snapshot.CreatedAt)
+	if state.ShutDownServer.IsNull() {
+		state.ShutDownServer = types.BoolValue(false)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *SnapShotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state SnapShotResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update Snapshot on the Clouding API, only name and description can be changed
+	if !plan.Name.Equal(state.Name) || !plan.Description.Equal(state.Description) {
+		err := r.client.UpdateSnapshot(ctx, plan.Id.ValueString(), plan.Name.ValueString(), plan.Description.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to update snapshot, got error: %s", err))
+			return
+		}
+	}
+
+	// Fetch the updated Snapshot from the Clouding API
+	snapshot, err := r.client.GetSnapshotID(ctx, plan.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to read snapshot id, got error: %s", err))
+		return
+	}
+
+	plan.Name = types.StringValue(snapshot.Name)
+	plan.Description = types.StringValue(snapshot.Description)
+	plan.SizeGb = types.Int64Value(snapshot.SizeGb)
+	plan.CreatedAt = types.StringValue(snapshot.CreatedAt)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *SnapShotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SnapShotResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	action, err := r.client.DeleteSnapshot(ctx, state.Id.ValueString())
+	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Snapshot %s already deleted", state.Id.ValueString()))
+			return
+		}
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to delete snapshot, got error: %s", err))
+		return
+	}
+	err = r.client.WaitForAction(ctx, &action, 5*time.Second)
+	if err != nil {
+		resp.Diagnostics.AddError("Clouding API Error", fmt.Sprintf("Unable to wait for snapshot action, got error: %s", err))
+		return
+	}
 }
 
 func (r *SnapShotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
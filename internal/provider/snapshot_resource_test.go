@@ -0,0 +1,231 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotResourceNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := &provider.SnapShotResource{}
+	testConfigureResource(t, r, testNotFoundHandler(t))
+	state := testResourceState(t, r, &provider.SnapShotResourceModel{
+		Id:             types.StringValue("xQ3jbNeJ2lDPqVWg"),
+		ServerID:       types.StringValue("Q7y1OZWlknXmk6l3"),
+		Name:           types.StringValue("testacc"),
+		Description:    types.StringValue("testacc"),
+		ShutDownServer: types.BoolValue(false),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{"create": types.StringType}),
+		},
+	})
+
+	readResp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull())
+
+	deleteResp := fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, &deleteResp)
+	assert.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+}
+
+// testSnapshotHandler answers as the Clouding API for the snapshot jDGPRJXLpGXeV5M1 of the server
+// Q7y1OZWlknXmk6l3 and records every request made. The snapshot action ends with actionStatus.
+func testSnapshotHandler(t *testing.T, actionStatus string, mu *sync.Mutex, requests *[]string) http.Handler {
+	snapshot := map[string]any{"name": "testacc", "description": "testacc"}
+	action := `{"id": "mR2Dn6xgLD9OMPyE", "status": "inProgress", "type": "snapshot", "resourceId": "jDGPRJXLpGXeV5M1", "resourceType": "snapshot"}`
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+
+		switch r.URL.Path {
+		case "/v1/servers/Q7y1OZWlknXmk6l3/snapshot":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			_, err := w.Write([]byte(action))
+			if err != nil {
+				t.Errorf("error writing response: %s", err)
+			}
+		case "/v1/actions/mR2Dn6xgLD9OMPyE":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(fmt.Sprintf(`{"id": "mR2Dn6xgLD9OMPyE", "status": %q, "resourceId": "jDGPRJXLpGXeV5M1"}`, actionStatus)))
+			if err != nil {
+				t.Errorf("error writing response: %s", err)
+			}
+		case "/v1/snapshots/jDGPRJXLpGXeV5M1":
+			switch r.Method {
+			case http.MethodPatch:
+				var body map[string]string
+				err := json.NewDecoder(r.Body).Decode(&body)
+				if err != nil {
+					t.Errorf("error decoding request: %s", err)
+				}
+				snapshot["name"] = body["newName"]
+				snapshot["description"] = body["newDescription"]
+				w.WriteHeader(http.StatusNoContent)
+			case http.MethodDelete:
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusAccepted)
+				_, err := w.Write([]byte(action))
+				if err != nil {
+					t.Errorf("error writing response: %s", err)
+				}
+			default:
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(fmt.Sprintf(`{"id": "jDGPRJXLpGXeV5M1", "name": %q, "description": %q, "sizeGb": 30, "createdAt": "2023-01-03T12:01:00.0000000Z"}`, snapshot["name"], snapshot["description"])))
+				if err != nil {
+					t.Errorf("error writing response: %s", err)
+				}
+			}
+		default:
+			testNotFoundHandler(t).ServeHTTP(w, r)
+		}
+	})
+}
+
+func testSnapshotModel() *provider.SnapShotResourceModel {
+	return &provider.SnapShotResourceModel{
+		Id:             types.StringValue("jDGPRJXLpGXeV5M1"),
+		ServerID:       types.StringValue("Q7y1OZWlknXmk6l3"),
+		Name:           types.StringValue("testacc"),
+		Description:    types.StringValue("testacc"),
+		ShutDownServer: types.BoolValue(false),
+		SizeGb:         types.Int64Value(30),
+		CreatedAt:      types.StringValue("2023-01-03T12:01:00.0000000Z"),
+		LastUpdated:    types.StringNull(),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{"create": types.StringType}),
+		},
+	}
+}
+
+func TestSnapshotResourceCreate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		actionStatus string
+		err          bool
+		sizeGb       types.Int64
+	}{
+		"completed": {
+			actionStatus: "completed",
+			sizeGb:       types.Int64Value(30),
+		},
+		"action failed": {
+			actionStatus: "error",
+			err:          true,
+			sizeGb:       types.Int64Null(),
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var mu sync.Mutex
+			var requests []string
+			r := &provider.SnapShotResource{}
+			testConfigureResource(t, r, testSnapshotHandler(t, test.actionStatus, &mu, &requests))
+			model := testSnapshotModel()
+			model.Id = types.StringUnknown()
+			model.SizeGb = types.Int64Unknown()
+			model.CreatedAt = types.StringUnknown()
+			model.LastUpdated = types.StringUnknown()
+			plan := testResourcePlan(t, r, model)
+
+			resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+			assert.Equal(t, test.err, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			// The snapshot is tracked with its configuration even when the wait fails
+			var state provider.SnapShotResourceModel
+			resp.State.Get(ctx, &state)
+			assert.Equal(t, "jDGPRJXLpGXeV5M1", state.Id.ValueString())
+			assert.Equal(t, "Q7y1OZWlknXmk6l3", state.ServerID.ValueString())
+			assert.Equal(t, "testacc", state.Name.ValueString())
+			assert.Equal(t, test.sizeGb, state.SizeGb)
+		})
+	}
+}
+
+func TestSnapshotResourceUpdate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := map[string]struct {
+		name        string
+		description string
+	}{
+		"rename": {
+			name:        "testacc-renamed",
+			description: "testacc",
+		},
+		"description": {
+			name:        "testacc",
+			description: "A snapshot of the server after mysql installation",
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var mu sync.Mutex
+			var requests []string
+			r := &provider.SnapShotResource{}
+			testConfigureResource(t, r, testSnapshotHandler(t, "completed", &mu, &requests))
+			state := testResourceState(t, r, testSnapshotModel())
+			model := testSnapshotModel()
+			model.Name = types.StringValue(test.name)
+			model.Description = types.StringValue(test.description)
+			model.LastUpdated = types.StringUnknown()
+			plan := testResourcePlan(t, r, model)
+
+			resp := fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, []string{
+				"PATCH /v1/snapshots/jDGPRJXLpGXeV5M1",
+				"GET /v1/snapshots/jDGPRJXLpGXeV5M1",
+			}, requests)
+
+			var updated provider.SnapShotResourceModel
+			resp.State.Get(ctx, &updated)
+			assert.Equal(t, test.name, updated.Name.ValueString())
+			assert.Equal(t, test.description, updated.Description.ValueString())
+		})
+	}
+}
+
+func TestSnapshotResourceDelete(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var mu sync.Mutex
+	var requests []string
+	r := &provider.SnapShotResource{}
+	testConfigureResource(t, r, testSnapshotHandler(t, "completed", &mu, &requests))
+	state := testResourceState(t, r, testSnapshotModel())
+
+	resp := fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, []string{
+		"DELETE /v1/snapshots/jDGPRJXLpGXeV5M1",
+		"GET /v1/actions/mR2Dn6xgLD9OMPyE",
+	}, requests)
+}
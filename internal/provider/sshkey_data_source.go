@@ -101,7 +101,7 @@ func (d *SshkeyDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	sshKey, err := d.client.GetSshKeyID(state.Id.ValueString())
+	sshKey, err := d.client.GetSshKeyID(ctx, state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get sshkey, got error: %s", err))
 		return
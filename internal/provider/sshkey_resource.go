@@ -137,7 +137,7 @@ func (r *SshKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		PrivateKey:    plan.PrivateKey.ValueString(),
 		HasPrivateKey: plan.HasPrivateKey.ValueBool(),
 	}
-	err := r.client.CreateSshKey(&sshKey)
+	err := r.client.CreateSshKey(ctx, &sshKey)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating ssh key",
@@ -173,8 +173,13 @@ func (r *SshKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	sshKey, err := r.client.GetSshKeyID(state.Id.ValueString())
+	sshKey, err := r.client.GetSshKeyID(ctx, state.Id.ValueString())
 	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Ssh key %s not found, removing it from the state", state.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Clouding Client Error",
 			fmt.Sprintf("Unable to read ssh key id, got error:  %s", err),
@@ -208,8 +213,12 @@ func (r *SshKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteSshKey(state.Id.ValueString())
+	err := r.client.DeleteSshKey(ctx, state.Id.ValueString())
 	if err != nil {
+		if clouding.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Ssh key %s already deleted", state.Id.ValueString()))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Clouding Client Error",
 			fmt.Sprintf("Unable to delete ssh key id, got error:  %s", err),
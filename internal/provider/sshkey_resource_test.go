@@ -1,13 +1,17 @@
 package provider_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccSshKey(t *testing.T) {
@@ -51,3 +55,23 @@ resource "clouding_sshkey" "test" {
 }
 `, name)
 }
+
+func TestSshKeyResourceNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := &provider.SshKeyResource{}
+	testConfigureResource(t, r, testNotFoundHandler(t))
+	state := testResourceState(t, r, &provider.SshKeyResourceModel{
+		Id:   types.StringValue("Dd8v0nXJ1924rayY"),
+		Name: types.StringValue("testacc"),
+	})
+
+	readResp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull())
+
+	deleteResp := fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, &deleteResp)
+	assert.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-clouding/internal/clouding"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &VolumeSizesDataSource{}
+
+func NewVolumeSizesDataSource() datasource.DataSource {
+	return &VolumeSizesDataSource{}
+}
+
+// VolumeSizesDataSource defines the data source implementation.
+type VolumeSizesDataSource struct {
+	client *clouding.API
+}
+
+// VolumeSizesDataSourceModel describes the data source data model.
+type VolumeSizesDataSourceModel struct {
+	VolumeSizes []VolumeSizeModel `tfsdk:"volume_sizes"`
+}
+
+type VolumeSizeModel struct {
+	SizeGb              types.Int64   `tfsdk:"size_gb"`
+	PricePerHour        types.Float64 `tfsdk:"price_per_hour"`
+	PricePerMonthApprox types.Float64 `tfsdk:"price_per_month_approx"`
+}
+
+func (d *VolumeSizesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_volume_sizes"
+}
+
+func (d *VolumeSizesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Volume sizes data source lists the allowed sizes of a server volume, used by the `volume.ssd_gb` of a server.",
+
+		Attributes: map[string]schema.Attribute{
+			"volume_sizes": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The list of allowed volume sizes.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"size_gb": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The size of the volume in gigabytes.",
+						},
+						"price_per_hour": schema.Float64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The price per hour of the volume size.",
+						},
+						"price_per_month_approx": schema.Float64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The approximate price per month of the volume size.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *VolumeSizesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clouding.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clouding.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *VolumeSizesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state VolumeSizesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sizes, err := d.client.ListVolumeSizes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Volume Sizes",
+			err.Error(),
+		)
+		return
+	}
+
+	state.VolumeSizes = make([]VolumeSizeModel, 0, len(sizes))
+	for _, size := range sizes {
+		state.VolumeSizes = append(state.VolumeSizes, VolumeSizeModel{
+			SizeGb:              types.Int64Value(size.SizeGb),
+			PricePerHour:        types.Float64Value(size.PricePerHour),
+			PricePerMonthApprox: types.Float64Value(size.PricePerMonthApprox),
+		})
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("read volume sizes data source, %d volume sizes found", len(state.VolumeSizes)))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
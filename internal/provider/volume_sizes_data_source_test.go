@@ -0,0 +1,26 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/renemontilva/terraform-provider-clouding/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumeSizesDataSource(t *testing.T) {
+	t.Parallel()
+	d := &provider.VolumeSizesDataSource{}
+	testConfigureDataSource(t, d, testVolumeSizesHandler(t))
+
+	resp := testReadDataSource(t, d, &provider.VolumeSizesDataSourceModel{})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state provider.VolumeSizesDataSourceModel
+	diags := resp.State.Get(context.Background(), &state)
+	assert.False(t, diags.HasError(), diags)
+
+	assert.Len(t, state.VolumeSizes, 5)
+	assert.Equal(t, int64(5), state.VolumeSizes[0].SizeGb.ValueInt64())
+	assert.Equal(t, int64(30), state.VolumeSizes[4].SizeGb.ValueInt64())
+}